@@ -1,6 +1,9 @@
 package calculator
 
-import "lz/model"
+import (
+	"context"
+	"lz/model"
+)
 
 // calculator 的接口
 type Calculator interface {
@@ -18,6 +21,8 @@ type Calculator interface {
 	GetCastingMachine() *CastingMachine
 	// 运行
 	Run()
+	// 停止计算协程并释放温度场，需要先停止计算
+	Close()
 	// 设置拉尾坯
 	SetStateTail() // todo
 	// 获取温度场数组的大小
@@ -30,4 +35,33 @@ type Calculator interface {
 	GenerateVerticalSlice2Data(reqData model.VerticalReqData) *VerticalSliceData2
 	// 坯壳厚度变化数据
 	GenerateShellCurves() *ShellCurvesData
+	// 扇形段坯壳厚度及液芯末端位置
+	GenerateSegmentsData() *SegmentsData
+	// 设置动态轻压下配置
+	SetSoftReductionCfg(cfg model.SoftReductionCfg)
+	// 动态轻压下方案
+	GenerateSoftReductionPlan() *SoftReductionPlan
+	// 喷嘴故障报告
+	GenerateNozzleReport() *NozzleReport
+	// 设置动态二冷控制配置
+	SetDynamicCoolingCfg(cfg model.DynamicCoolingCfg)
+	// 动态二冷控制动作
+	GenerateDynamicCoolingData() *DynamicCoolingData
+	// 设置水表
+	SetWaterTables(tables []model.WaterTable)
+	// 切换二冷控制模式
+	SetCoolingMode(mode string) error
+	GetCoolingMode() string
+	// 离线冷却制度优化
+	Optimize(ctx context.Context, cfg model.OptimizeCfg, progress func(OptimizeProgress)) (*OptimizeResult, error)
+	// 过热度历史
+	GenerateSuperheatHistory() *SuperheatHistory
+	// 开浇、停浇和换包事件
+	HeatEvent(event model.HeatEvent) error
+	// 炉次报告
+	GenerateHeatReport() *HeatReport
+	// 关键指标
+	GenerateIndicators() *Indicators
+	// 降采样后的温度场
+	GenerateFieldSnapshot(zStride, xyStride int) *FieldSnapshot
 }
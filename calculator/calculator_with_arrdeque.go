@@ -35,6 +35,7 @@ type calculatorWithArrDeque struct {
 	Field         *deque.ArrDeque
 	thermalField  *deque.ArrDeque // 温度场容器
 	thermalField1 *deque.ArrDeque
+	metas         *sliceMetaQueue // 切片信息，与温度场同步增删
 
 	alternating bool // 每计算一个 ▲t 进行一次异或运算
 
@@ -57,7 +58,21 @@ type calculatorWithArrDeque struct {
 
 	e executor
 
+	softReductionCfg  model.SoftReductionCfg  // 动态轻压下配置
+	dynamicCoolingCfg model.DynamicCoolingCfg // 动态二冷控制配置
+	controller        coolingController       // 动态二冷控制器状态
+	coolingMode       string                  // 二冷控制模式
+	waterTables       []model.WaterTable      // 水表
+	tableV            int64                   // 上次按水表计算时的拉速，-1 表示需要重新计算
+	heats             heatTracker             // 炉次跟踪
+	metrics           solverMetrics           // 运行指标
+
 	mu sync.Mutex // 保护 push data时对温度数据的并发访问
+
+	fieldMu sync.RWMutex // 计算一个时间步长时持有写锁，读取温度场时持有读锁，加锁顺序为先 fieldMu 后 c.mu
+
+	runMu  sync.Mutex // 计算过程中持有，Close 等待计算结束
+	closed bool       // 已经释放计算协程和温度场
 }
 
 // 初始化温度场计算器
@@ -70,6 +85,7 @@ func NewCalculatorWithArrDeque(e executor) *calculatorWithArrDeque {
 	// 初始化数据结构
 	c.thermalField = deque.NewArrDeque(ZLength / ZStep)
 	c.thermalField1 = deque.NewArrDeque(ZLength / ZStep)
+	c.metas = newSliceMetaQueue(ZLength / ZStep)
 
 	c.Field = c.thermalField
 	c.alternating = true
@@ -85,6 +101,12 @@ func NewCalculatorWithArrDeque(e executor) *calculatorWithArrDeque {
 
 	c.runningState = stateNotRunning // 未开始运行，只是完成初始化
 
+	c.softReductionCfg = defaultSoftReductionCfg
+	c.coolingMode = model.CoolingModeManual
+	c.tableV = -1
+	c.metrics = newSolverMetrics()
+	liveCalculators.Add(1)
+
 	log.WithField("init_cost", time.Since(start)).Debug("温度场计算器初始化耗时")
 	return c
 }
@@ -122,7 +144,6 @@ func (c *calculatorWithArrDeque) InitPushData(coordinate model.Coordinate) {
 // 获取钢种参数
 func (c *calculatorWithArrDeque) getParameter(z int) *Parameter {
 	if c.runningState == stateRunning {
-		c.steel1.SetParameter(z)
 		return c.steel1.Parameter
 	} else if c.runningState == stateRunningWithTwoSteel { // 处理两种钢种的情况
 		// todo
@@ -153,6 +174,8 @@ func (c *calculatorWithArrDeque) getFieldEnd() int {
 
 // 获取切片数
 func (c *calculatorWithArrDeque) GetFieldSize() int {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
 	return c.Field.Size()
 }
 
@@ -228,8 +251,10 @@ func (c *calculatorWithArrDeque) calculateWideSurfaceEnergy(wideSurfaceH float32
 	averageTemp := (c.castingMachine.CoolerConfig.WideSurfaceIn + c.castingMachine.CoolerConfig.WideSurfaceOut) / 2
 	c.Field.Traverse(func(z int, item *model.ItemType) {
 		initialQ = 1 / (ROfWater(float64(c.castingMachine.CoolerConfig.WideWaterVolume), 0.005, float64(averageTemp)) + ROfCu() + 1/wideSurfaceH) * (item[Width/YStep-1][0] - averageTemp)
+		// 调宽后的切片只在半宽以内与宽面铜板换热
+		right := c.sliceRight(z)
 		j := 0
-		for ; j < Length/XStep; j++ {
+		for ; j <= right; j++ {
 			if item[0][j] > c.steel1.LiquidPhaseTemperature {
 				c.steel1.Parameter.Q[z][j] = initialQ
 				wideSurfaceEnergy += c.steel1.Parameter.Q[z][j] * float32(XStep*ZStep) / 1e6
@@ -238,8 +263,8 @@ func (c *calculatorWithArrDeque) calculateWideSurfaceEnergy(wideSurfaceH float32
 			}
 		}
 		start := j - 1
-		for ; j < Length/XStep; j++ {
-			c.steel1.Parameter.Q[z][j] = initialQ - initialQ*0.7*(float32((j-start)*XStep)-float32(XStep)/2)/float32((Length/XStep-1-start)*XStep)
+		for ; j <= right; j++ {
+			c.steel1.Parameter.Q[z][j] = initialQ - initialQ*0.7*(float32((j-start)*XStep)-float32(XStep)/2)/float32((right-start)*XStep)
 			wideSurfaceEnergy += c.steel1.Parameter.Q[z][j] * float32(XStep*ZStep) / 1e6
 		}
 	}, 0, (c.castingMachine.Coordinate.MdLength-int(c.castingMachine.Coordinate.LevelHeight))/ZStep)
@@ -273,6 +298,10 @@ func (c *calculatorWithArrDeque) calculateNarrowSurfaceEnergy(narrowSurfaceH flo
 
 // 在线计算热流密度和综合换热系数
 func (c *calculatorWithArrDeque) calculateQAndHeffOnline() {
+	// 运行时修改的二冷水量、喷嘴状态和结晶器液面在此生效
+	c.castingMachine.applyPendingWater()
+	c.castingMachine.applyPendingNozzle()
+	c.castingMachine.applyMoldLevel()
 	// 结晶器先计算热流密度Q再计算综合换热系数Heff
 	c.calculateQOnlineAtMd()
 	c.calculateHeffOnlineAtMd()
@@ -374,7 +403,7 @@ func (c *calculatorWithArrDeque) calculateHeffOnlineAtSecondaryCoolingZone() {
 		Ts_ = float64(c.calculateTs(preDistance, "Wide"))                                                                                   // 辊子对应铸坯表面平均温度
 		Hbr = calculateHbr(Ts_, envTemp, c.steel1.Parameter)                                                                                // 计算空气换热系数
 		S = float64(sprayWidth*Ds) / 1e6                                                                                                    // 喷淋面积
-		Volume = float64(cooingWaterCfg[item.CoolingZone-1].InnerArcWaterVolume / float32(coolingZoneCfg[item.CoolingZone-1].End-coolingZoneCfg[item.CoolingZone-1].Start+1) / 60.0)
+		Volume = float64(cooingWaterCfg[item.CoolingZone-1].InnerArcWaterVolume / float32(coolingZoneCfg[item.CoolingZone-1].End-coolingZoneCfg[item.CoolingZone-1].Start+1) / 60.0 * item.FlowFactor())
 		R0 = float64(item.InnerDiameter) / 2.0 / 10.0         // 辊子半径
 		T = float64(c.calculateT(preDistance, item.Distance)) // 计算喷淋区域平均温度
 		// step2. 确定辊间距对应影响的切片范围，然后更新
@@ -383,6 +412,15 @@ func (c *calculatorWithArrDeque) calculateHeffOnlineAtSecondaryCoolingZone() {
 		endSliceIndex = int(curDistance / float32(ZStep))
 		preDistance = curDistance
 		hci := calculateHci(Hbr, calculateHsr(R0, float64(DE), Ts_), L, DE)
+		// 喷嘴堵死或关闭时按空冷处理
+		if item.FlowFactor() == 0 {
+			for z := startSliceIndex; z < endSliceIndex; z++ {
+				for j := 0; j < Length/XStep; j++ {
+					c.steel1.Parameter.Heff[z][j] = hci
+				}
+			}
+			continue
+		}
 		if cooingWaterCfg[item.CoolingZone-1].InnerArcWaterVolume == 0.0 {
 			for z := startSliceIndex; z < endSliceIndex; z++ {
 				for j := 0; j < Length/XStep; j++ {
@@ -392,9 +430,9 @@ func (c *calculatorWithArrDeque) calculateHeffOnlineAtSecondaryCoolingZone() {
 			}
 		}
 		heff := calculateAverageHeffHelper(L, AB, BC, CD, DE, Hbr, item.Medium, S, Volume, T, float64(Ds), R0, Ts_) // 计算平均综合换热系数
-		Volume1 := float64(cooingWaterCfg[item.CoolingZone-1].Fuqie1Volume / float32(coolingZoneCfg[item.CoolingZone-1].End-coolingZoneCfg[item.CoolingZone-1].Start+1) / 60.0)
+		Volume1 := float64(cooingWaterCfg[item.CoolingZone-1].Fuqie1Volume / float32(coolingZoneCfg[item.CoolingZone-1].End-coolingZoneCfg[item.CoolingZone-1].Start+1) / 60.0 * item.FlowFactor())
 		heff1 := calculateAverageHeffHelper(L, AB, BC, CD, DE, Hbr, item.Medium, S, Volume1, T, float64(Ds), R0, Ts_) // 计算幅切1平均综合换热系数
-		Volume2 := float64(cooingWaterCfg[item.CoolingZone-1].Fuqie2Volume / float32(coolingZoneCfg[item.CoolingZone-1].End-coolingZoneCfg[item.CoolingZone-1].Start+1) / 60.0)
+		Volume2 := float64(cooingWaterCfg[item.CoolingZone-1].Fuqie2Volume / float32(coolingZoneCfg[item.CoolingZone-1].End-coolingZoneCfg[item.CoolingZone-1].Start+1) / 60.0 * item.FlowFactor())
 		heff2 := calculateAverageHeffHelper(L, AB, BC, CD, DE, Hbr, item.Medium, S, Volume2, T, float64(Ds), R0, Ts_)                         // 计算幅切2平均综合换热系数
 		sprayWidth1 := min(item.AlterSpraySection1.RightLimit-item.AlterSpraySection1.LeftLimit, float32(c.castingMachine.Coordinate.Length)) // 幅切1喷淋宽度
 		sprayWidth2 := min(item.AlterSpraySection2.RightLimit-item.AlterSpraySection2.LeftLimit, float32(c.castingMachine.Coordinate.Length)) // 幅切2喷淋宽度
@@ -449,7 +487,7 @@ func (c *calculatorWithArrDeque) calculateHeffOnlineAtSecondaryCoolingZone() {
 		Ts_ = float64(c.calculateTs(preDistance, "Narrow"))                                    // 辊子对应铸坯表面平均温度
 		Hbr = calculateHbr(Ts_, envTemp, c.steel1.Parameter)                                   // 计算空气换热系数
 		S = float64(sprayWidth*Ds) / 1e6                                                       // 喷淋面积
-		Volume = float64(cooingWaterCfg[item.CoolingZone-1].NarrowSideWaterVolume / float32(len(narrowItems)) / 60.0)
+		Volume = float64(cooingWaterCfg[item.CoolingZone-1].NarrowSideWaterVolume / float32(len(narrowItems)) / 60.0 * item.FlowFactor())
 		R0 = float64(item.Diameter) / 2.0 / 10.0                                // 辊子半径
 		T = float64(c.calculateT(preDistance, preDistance+item.RollerDistance)) // 计算喷淋区域平均温度
 		// step2. 确定辊间距对应影响的切片范围，然后更新
@@ -459,6 +497,10 @@ func (c *calculatorWithArrDeque) calculateHeffOnlineAtSecondaryCoolingZone() {
 		preDistance = curDistance
 		heff := calculateAverageHeffHelper(W, AB, BC, CD, DE, Hbr, Water, S, Volume, T, float64(Ds), R0, Ts_) // 计算平均综合换热系数
 		hci := calculateHci(Hbr, calculateHsr(R0, float64(DE), Ts_), W, DE)
+		// 喷嘴堵死或关闭时按空冷处理
+		if item.FlowFactor() == 0 {
+			heff = hci
+		}
 		log.Debug("窄面平均综合换热系数：", heff, hci)
 		for z := startSliceIndex; z <= endSliceIndex; z++ {
 			for i := 0; i < int(sprayWidth/2)/YStep; i++ {
@@ -635,9 +677,16 @@ func (c *calculatorWithArrDeque) calculateHeffOnlineAtMd() {
 
 // 运行计算
 func (c *calculatorWithArrDeque) Run() {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+	if c.closed {
+		return
+	}
 	c.runningState = stateRunning
 	var duration, calcDuration, gap time.Duration
 	var deltaT float32
+	c.metrics.start()
+	defer c.metrics.remove()
 LOOP:
 	for {
 		select {
@@ -645,6 +694,9 @@ LOOP:
 			c.runningState = stateSuspended
 			break LOOP
 		default:
+			c.fieldMu.Lock()
+			stepStart := time.Now()
+			var timeStepCost time.Duration // 没有切片时直接生成切片，不计算时间步长
 			if c.Field.Size() == 0 { // 计算时间等于0，意味着还没有切片产生，此时可以等待产生一个切片再计算
 				log.Debug("切片数为0，此时直接生成一个切片")
 				duration += OneSliceDuration
@@ -656,7 +708,7 @@ LOOP:
 				log.Debug("Q: ", c.steel1.Parameter.Q[c.Field.Size()-1][Length/XStep:Length/XStep+Width/YStep])
 				log.Debug("Heff: ", c.steel1.Parameter.Heff[c.Field.Size()-1][:Length/XStep])
 				log.Debug("Heff: ", c.steel1.Parameter.Heff[c.Field.Size()-1][Length/XStep:Length/XStep+Width/YStep])
-				deltaT, _ = c.calculateTimeStep()
+				deltaT, timeStepCost = c.calculateTimeStep()
 				calcDuration = c.e.dispatchTask(deltaT, 0, c.Field.Size()) // c.ThermalField.Field 最开始赋值为 ThermalField对应的指针
 				log.Debug("计算单次时间：", calcDuration.Milliseconds(), "ms")
 				gap = time.Duration(int64(deltaT*1e9)) - calcDuration
@@ -674,10 +726,21 @@ LOOP:
 			} else {
 				c.Field = c.thermalField
 			}
+			c.maskNarrowedSlices()
 			c.updateSliceInfo(time.Duration(int64(deltaT * 1e9)))
+			c.controlCooling(time.Duration(int64(deltaT * 1e9)))
+			c.updateHeats(time.Duration(int64(deltaT * 1e9)))
 			c.alternating = !c.alternating // 仅在这里修改
 			log.WithFields(log.Fields{"deltaT": deltaT, "cost": duration.Milliseconds()}).Debug("计算一次")
-			if duration > time.Second*4 {
+			c.metrics.observeStep(time.Since(stepStart), timeStepCost, deltaT, c.Field.Size())
+			push := duration > time.Second*4
+			if push {
+				c.metrics.observePush()
+				c.comparePyrometers()
+			}
+			// 推送前释放写锁，推送时需要读取温度场
+			c.fieldMu.Unlock()
+			if push {
 				c.calcHub.PushSignal()
 				duration = time.Second * 0
 			}
@@ -685,9 +748,32 @@ LOOP:
 	}
 }
 
+// 停止计算协程并释放温度场，正在计算时先发送停止信号，等待计算结束后释放，释放后不能再使用
+func (c *calculatorWithArrDeque) Close() {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.e.stop()
+	c.fieldMu.Lock()
+	c.Field, c.thermalField, c.thermalField1 = nil, nil, nil
+	c.fieldMu.Unlock()
+	liveCalculators.Add(-1)
+	log.Debug("释放温度场计算器")
+}
+
 // 根据经过的时间结合拉速更新切面数量
 func (c *calculatorWithArrDeque) updateSliceInfo(calcDuration time.Duration) {
+	// 按拉速曲线更新拉速
+	c.castingMachine.advanceSpeedSchedule(calcDuration)
 	v := c.castingMachine.CoolerConfig.V // m/min -> mm/s
+	// 推进调宽过程，得到新切片的宽度
+	moldWidth := c.castingMachine.advanceWidthChange(calcDuration)
+	// 推进中间包温度模型，得到新切片的浇铸温度
+	pouring := c.castingMachine.advanceTundish(calcDuration, c.tundishFloor())
+	heatID := c.currentHeatID()
 	var distance int64
 	distance = v*calcDuration.Microseconds() + c.reminder
 	if distance == 0 {
@@ -705,12 +791,15 @@ func (c *calculatorWithArrDeque) updateSliceInfo(calcDuration time.Duration) {
 				c.thermalField.AddFirst(-1) // 使用-1代表该切片是空的
 				c.thermalField1.RemoveLast()
 				c.thermalField1.AddFirst(-1)
+				c.metas.removeLast()
+				c.metas.addFirst(sliceMeta{})
 				// 当没有温度不为空的切片时，需要退出
 				// 需要结合有没有新的钢种注入
 				// 遍历时需要跳过为空的切片
 			} else {
 				c.thermalField.AddFirst(-1)
 				c.thermalField1.AddFirst(-1)
+				c.metas.addFirst(sliceMeta{})
 			}
 			if c.start < c.end {
 				c.start++
@@ -726,8 +815,10 @@ func (c *calculatorWithArrDeque) updateSliceInfo(calcDuration time.Duration) {
 		for i := 0; i < add; i++ {
 			c.thermalField.RemoveLast()
 			c.thermalField1.RemoveLast()
-			c.thermalField.AddFirst(c.castingMachine.CoolerConfig.StartTemperature)
-			c.thermalField1.AddFirst(c.castingMachine.CoolerConfig.StartTemperature)
+			c.thermalField.AddFirst(pouring)
+			c.thermalField1.AddFirst(pouring)
+			c.metas.removeLast()
+			c.metas.addFirst(sliceMeta{Width: moldWidth, PouringTemperature: pouring, HeatID: heatID})
 		}
 	} else {
 		log.Debug("切片未满, updateSliceInfo: 新增切片数:", add)
@@ -735,12 +826,14 @@ func (c *calculatorWithArrDeque) updateSliceInfo(calcDuration time.Duration) {
 			if c.Field.IsFull() {
 				c.thermalField.RemoveLast()
 				c.thermalField1.RemoveLast()
-				c.thermalField.AddFirst(c.castingMachine.CoolerConfig.StartTemperature)
-				c.thermalField1.AddFirst(c.castingMachine.CoolerConfig.StartTemperature)
+				c.thermalField.AddFirst(pouring)
+				c.thermalField1.AddFirst(pouring)
+				c.metas.removeLast()
 			} else {
-				c.thermalField.AddFirst(c.castingMachine.CoolerConfig.StartTemperature)
-				c.thermalField1.AddFirst(c.castingMachine.CoolerConfig.StartTemperature)
+				c.thermalField.AddFirst(pouring)
+				c.thermalField1.AddFirst(pouring)
 			}
+			c.metas.addFirst(sliceMeta{Width: moldWidth, PouringTemperature: pouring, HeatID: heatID})
 			if c.end < ZLength/ZStep {
 				c.end++
 			}
@@ -765,10 +858,10 @@ func (c *calculatorWithArrDeque) calculatePointLT(deltaT float32, z int, slice *
 
 	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[Width/YStep-1][0]) - deltaHlt)
 	if c.alternating {
-		c.thermalField1.Set(z, Width/YStep-1, 0, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, Width/YStep-1, 0, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
 		// 需要修改焓的变化到温度变化k映射关系
-		c.thermalField.Set(z, Width/YStep-1, 0, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, Width/YStep-1, 0, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
@@ -787,70 +880,70 @@ func (c *calculatorWithArrDeque) calculatePointTA(deltaT float32, x, z int, slic
 
 	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[Width/YStep-1][x]) - deltaHta)
 	if c.alternating {
-		c.thermalField1.Set(z, Width/YStep-1, x, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, Width/YStep-1, x, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
 		// 需要修改焓的变化到温度变化k映射关系
-		c.thermalField.Set(z, Width/YStep-1, x, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, Width/YStep-1, x, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
-// 计算right top点的温度变化
-func (c *calculatorWithArrDeque) calculatePointRT(deltaT float32, z int, slice *model.ItemType, parameter *Parameter, zone int, electromagneticStirringFactor float32) {
-	var index = int(slice[Width/YStep-1][Length/XStep-1]) - 1
-	var index1 = int(slice[Width/YStep-1][Length/XStep-2]) - 1
-	var index2 = int(slice[Width/YStep-2][Length/XStep-1]) - 1
+// 计算right top点的温度变化，right 为切片窄面所在的列
+func (c *calculatorWithArrDeque) calculatePointRT(deltaT float32, right, z int, slice *model.ItemType, parameter *Parameter, zone int, electromagneticStirringFactor float32) {
+	var index = int(slice[Width/YStep-1][right]) - 1
+	var index1 = int(slice[Width/YStep-1][right-1]) - 1
+	var index2 = int(slice[Width/YStep-2][right]) - 1
 
-	var deltaHrt = getLambda(index, index1, Length/XStep-1, Width/YStep-1, Length/XStep-2, Width/YStep-1, parameter, zone, electromagneticStirringFactor)*(slice[Width/YStep-1][Length/XStep-1]-slice[Width/YStep-1][Length/XStep-2])/(stdXStep*(getEx(Length/XStep-2)+getEx(Length/XStep-1))) +
-		getLambda(index, index2, Length/XStep-1, Width/YStep-1, Length/XStep-1, Width/YStep-2, parameter, zone, electromagneticStirringFactor)*(slice[Width/YStep-1][Length/XStep-1]-slice[Width/YStep-2][Length/XStep-1])/(stdYStep*(getEy(Width/YStep-2)+getEy(Width/YStep-1))) +
-		parameter.GetQ(Length/XStep-1, Width/YStep, z)/(2*stdYStep) +
+	var deltaHrt = getLambda(index, index1, right, Width/YStep-1, right-1, Width/YStep-1, parameter, zone, electromagneticStirringFactor)*(slice[Width/YStep-1][right]-slice[Width/YStep-1][right-1])/(stdXStep*(getEx(right-1)+getEx(right))) +
+		getLambda(index, index2, right, Width/YStep-1, right, Width/YStep-2, parameter, zone, electromagneticStirringFactor)*(slice[Width/YStep-1][right]-slice[Width/YStep-2][right])/(stdYStep*(getEy(Width/YStep-2)+getEy(Width/YStep-1))) +
+		parameter.GetQ(right, Width/YStep, z)/(2*stdYStep) +
 		parameter.GetQ(Length/XStep-1, Width/YStep-1, z)/(2*stdXStep)
 	deltaHrt = deltaHrt * (2 * deltaT / parameter.Density[index])
 
-	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[Width/YStep-1][Length/XStep-1]) - deltaHrt)
+	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[Width/YStep-1][right]) - deltaHrt)
 	if c.alternating { // 需要修改焓的变化到温度变化的映射关系)
-		c.thermalField1.Set(z, Width/YStep-1, Length/XStep-1, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, Width/YStep-1, right, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
-		c.thermalField.Set(z, Width/YStep-1, Length/XStep-1, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, Width/YStep-1, right, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
-// 计算右表面点的温度变化
-func (c *calculatorWithArrDeque) calculatePointRA(deltaT float32, y, z int, slice *model.ItemType, parameter *Parameter, zone int, electromagneticStirringFactor float32) {
-	var index = int(slice[y][Length/XStep-1]) - 1
-	var index1 = int(slice[y][Length/XStep-2]) - 1
-	var index2 = int(slice[y-1][Length/XStep-1]) - 1
-	var index3 = int(slice[y+1][Length/XStep-1]) - 1
+// 计算右表面点的温度变化，right 为切片窄面所在的列
+func (c *calculatorWithArrDeque) calculatePointRA(deltaT float32, right, y, z int, slice *model.ItemType, parameter *Parameter, zone int, electromagneticStirringFactor float32) {
+	var index = int(slice[y][right]) - 1
+	var index1 = int(slice[y][right-1]) - 1
+	var index2 = int(slice[y-1][right]) - 1
+	var index3 = int(slice[y+1][right]) - 1
 
-	var deltaHra = getLambda(index, index1, Length/XStep-1, y, Length/XStep-2, y, parameter, zone, electromagneticStirringFactor)*(slice[y][Length/XStep-1]-slice[y][Length/XStep-2])/(stdXStep*(getEx(Length/XStep-2)+getEx(Length/XStep-1))) +
-		getLambda(index, index2, Length/XStep-1, y, Length/XStep-1, y-1, parameter, zone, electromagneticStirringFactor)*(slice[y][Length/XStep-1]-slice[y-1][Length/XStep-1])/(stdYStep*(getEy(y-1)+getEy(y))) +
-		getLambda(index, index3, Length/XStep-1, y, Length/XStep-1, y+1, parameter, zone, electromagneticStirringFactor)*(slice[y][Length/XStep-1]-slice[y+1][Length/XStep-1])/(stdYStep*(getEy(y+1)+getEy(y))) +
+	var deltaHra = getLambda(index, index1, right, y, right-1, y, parameter, zone, electromagneticStirringFactor)*(slice[y][right]-slice[y][right-1])/(stdXStep*(getEx(right-1)+getEx(right))) +
+		getLambda(index, index2, right, y, right, y-1, parameter, zone, electromagneticStirringFactor)*(slice[y][right]-slice[y-1][right])/(stdYStep*(getEy(y-1)+getEy(y))) +
+		getLambda(index, index3, right, y, right, y+1, parameter, zone, electromagneticStirringFactor)*(slice[y][right]-slice[y+1][right])/(stdYStep*(getEy(y+1)+getEy(y))) +
 		parameter.GetQ(Length/XStep-1, y, z)/(2*stdXStep)
 	deltaHra = deltaHra * (2 * deltaT / parameter.Density[index])
 
-	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[y][Length/XStep-1]) - deltaHra)
+	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[y][right]) - deltaHra)
 	if c.alternating { // 需要修改焓的变化到温度变化的映射关系
-		c.thermalField1.Set(z, y, Length/XStep-1, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, y, right, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
-		c.thermalField.Set(z, y, Length/XStep-1, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, y, right, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
-// 计算right bottom点的温度变化
-func (c *calculatorWithArrDeque) calculatePointRB(deltaT float32, z int, slice *model.ItemType, parameter *Parameter, zone int, electromagneticStirringFactor float32) {
-	var index = int(slice[0][Length/XStep-1]) - 1
-	var index1 = int(slice[0][Length/XStep-2]) - 1
-	var index2 = int(slice[1][Length/XStep-1]) - 1
+// 计算right bottom点的温度变化，right 为切片窄面所在的列
+func (c *calculatorWithArrDeque) calculatePointRB(deltaT float32, right, z int, slice *model.ItemType, parameter *Parameter, zone int, electromagneticStirringFactor float32) {
+	var index = int(slice[0][right]) - 1
+	var index1 = int(slice[0][right-1]) - 1
+	var index2 = int(slice[1][right]) - 1
 
-	var deltaHrb = getLambda(index, index1, Length/XStep-1, 0, Length/XStep-2, 0, parameter, zone, electromagneticStirringFactor)*(slice[0][Length/XStep-1]-slice[0][Length/XStep-2])/(stdXStep*(getEx(Length/XStep-2)+getEx(Length/XStep-1))) +
-		getLambda(index, index2, Length/XStep-1, 0, Length/XStep-1, 1, parameter, zone, electromagneticStirringFactor)*(slice[0][Length/XStep-1]-slice[1][Length/XStep-1])/(stdYStep*(getEy(1)+getEy(0))) +
+	var deltaHrb = getLambda(index, index1, right, 0, right-1, 0, parameter, zone, electromagneticStirringFactor)*(slice[0][right]-slice[0][right-1])/(stdXStep*(getEx(right-1)+getEx(right))) +
+		getLambda(index, index2, right, 0, right, 1, parameter, zone, electromagneticStirringFactor)*(slice[0][right]-slice[1][right])/(stdYStep*(getEy(1)+getEy(0))) +
 		parameter.GetQ(Length/XStep-1, 0, z)/(2*stdXStep)
 	deltaHrb = deltaHrb * (2 * deltaT / parameter.Density[index])
 
-	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[0][Length/XStep-1]) - deltaHrb)
+	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[0][right]) - deltaHrb)
 	if c.alternating { // 需要修改焓的变化到温度变化的映射关系
-		c.thermalField1.Set(z, 0, Length/XStep-1, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, 0, right, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
-		c.thermalField.Set(z, 0, Length/XStep-1, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, 0, right, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
@@ -867,9 +960,9 @@ func (c *calculatorWithArrDeque) calculatePointBA(deltaT float32, x, z int, slic
 
 	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[0][x]) - deltaHba)
 	if c.alternating { // 需要修改焓的变化到温度变化的映射关系)
-		c.thermalField1.Set(z, 0, x, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, 0, x, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
-		c.thermalField.Set(z, 0, x, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, 0, x, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
@@ -884,9 +977,9 @@ func (c *calculatorWithArrDeque) calculatePointLB(deltaT float32, z int, slice *
 
 	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[0][0]) - deltaHlb)
 	if c.alternating { // 需要修改焓的变化到温度变化的映射关系)
-		c.thermalField1.Set(z, 0, 0, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, 0, 0, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
-		c.thermalField.Set(z, 0, 0, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, 0, 0, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
@@ -903,9 +996,9 @@ func (c *calculatorWithArrDeque) calculatePointLA(deltaT float32, y, z int, slic
 
 	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[y][0]) - deltaHla)
 	if c.alternating { // 需要修改焓的变化到温度变化的映射关系)
-		c.thermalField1.Set(z, y, 0, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, y, 0, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
-		c.thermalField.Set(z, y, 0, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, y, 0, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
 
@@ -924,8 +1017,8 @@ func (c *calculatorWithArrDeque) calculatePointIN(deltaT float32, x, y, z int, s
 
 	targetTemp := parameter.Enthalpy2Temp(parameter.Temp2Enthalpy(slice[y][x]) - deltaHin)
 	if c.alternating { // 需要修改焓的变化到温度变化的映射关系)
-		c.thermalField1.Set(z, y, x, targetTemp, parameter.TemperatureBottom)
+		c.thermalField1.Set(z, y, x, targetTemp, c.steel1.TemperatureBottom(z))
 	} else {
-		c.thermalField.Set(z, y, x, targetTemp, parameter.TemperatureBottom)
+		c.thermalField.Set(z, y, x, targetTemp, c.steel1.TemperatureBottom(z))
 	}
 }
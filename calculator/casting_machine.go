@@ -2,8 +2,11 @@ package calculator
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	log "github.com/sirupsen/logrus"
 	"lz/model"
+	"sync"
 	"time"
 )
 
@@ -34,6 +37,19 @@ var (
 type CastingMachine struct {
 	Coordinate   model.Coordinate // 铸机的一些尺寸配置
 	CoolerConfig model.CoolerCfg
+	Segments     []Segment // 扇形段
+	MoldWidth    float32   // 当前结晶器宽度（宽面长度）mm
+	Geometry     *Geometry // 铸流中心线几何
+
+	widthChange   *widthChange                               // 正在进行的调宽，没有时为 nil
+	speedSchedule *speedSchedule                             // 正在播放的拉速曲线，没有时为 nil
+	pendingWater  map[int]model.SecondaryCoolingWaterSection // 待生效的二冷水量，冷却区下标 -> 水量
+	pendingNozzle *model.NozzleCfg                           // 待生效的喷嘴布置，没有时为 nil
+	tundish       *tundish                                   // 中间包温度模型，没有时为 nil
+	history       tundishHistory                             // 浇铸温度历史
+	moldLevel     moldLevel                                  // 结晶器液面
+	pyrometers    pyrometers                                 // 测温仪
+	mu            sync.Mutex
 }
 
 func NewCastingMachine() *CastingMachine {
@@ -57,10 +73,33 @@ func NewCastingMachine() *CastingMachine {
 
 func (c *CastingMachine) SetFromJson(coordinate model.Coordinate) {
 	c.Coordinate = coordinate
+	c.MoldWidth = float32(coordinate.Length)
+	c.widthChange = nil
+	c.Geometry = NewGeometry(coordinate)
 	log.Info("铸机尺寸配置: ", c.Coordinate)
 }
 
-func (c *CastingMachine) SetCoolerConfig(env model.Env, nozzleCfgData []byte) {
+// 设置冷却参数，出错时不修改任何参数
+func (c *CastingMachine) SetCoolerConfig(env model.Env, nozzleCfgData []byte) error {
+	var nozzleCfg model.NozzleCfg
+	err := json.Unmarshal(nozzleCfgData, &nozzleCfg)
+	if err != nil {
+		log.Error("err:", err)
+		return err
+	}
+	var rollerNum int
+	wideItems := nozzleCfg.WideItems
+	coolingZoneCfg := make([]model.CoolingZone, len(env.CoolingZoneCfg))
+	copy(coolingZoneCfg, env.CoolingZoneCfg)
+	for i := range coolingZoneCfg {
+		rollerNum = coolingZoneCfg[i].End
+		if rollerNum >= 1 && rollerNum <= len(wideItems) {
+			coolingZoneCfg[i].EndDistance = wideItems[rollerNum-1].Distance
+		} else {
+			log.Error("err:", "辊子下标越界")
+			return errors.New("辊子下标越界")
+		}
+	}
 	c.Coordinate.LevelHeight = env.LevelHeight
 	c.CoolerConfig.StartTemperature = env.StartTemperature
 	// 结晶器区
@@ -70,25 +109,14 @@ func (c *CastingMachine) SetCoolerConfig(env model.Env, nozzleCfgData []byte) {
 	c.CoolerConfig.WideSurfaceIn = env.Md.WideSurfaceIn
 	c.CoolerConfig.WideSurfaceOut = env.Md.WideSurfaceOut
 	c.CoolerConfig.WideWaterVolume = env.Md.WideSurfaceVolume
-	// 二冷区
+	// 二冷区，丢弃按原来的冷却参数排队的喷嘴状态和水量修改
+	c.mu.Lock()
 	c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = env.SecondaryCoolingWaterCfg
-	err := json.Unmarshal(nozzleCfgData, &c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg)
-	if err != nil {
-		log.Error("err:", err)
-		return
-	}
-	var rollerNum int
-	wideItems := c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg.WideItems
-	for i := range env.CoolingZoneCfg {
-		rollerNum = env.CoolingZoneCfg[i].End
-		if rollerNum <= len(wideItems) {
-			env.CoolingZoneCfg[i].EndDistance = wideItems[rollerNum-1].Distance
-		} else {
-			log.Error("err:", "辊子下标越界")
-			return
-		}
-	}
-	c.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg = env.CoolingZoneCfg
+	c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg = nozzleCfg
+	c.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg = coolingZoneCfg
+	c.pendingNozzle = nil
+	c.pendingWater = nil
+	c.mu.Unlock()
 	log.WithFields(log.Fields{
 		"StartTemperature":        env.StartTemperature,
 		"NarrowSurfaceIn":         env.Md.NarrowSurfaceIn,
@@ -99,17 +127,22 @@ func (c *CastingMachine) SetCoolerConfig(env model.Env, nozzleCfgData []byte) {
 		"WideWaterVolume":         env.Md.WideSurfaceVolume,
 		"SecondaryCoolingZoneCfg": c.CoolerConfig.SecondaryCoolingZoneCfg,
 	}).Info("设置冷却参数")
+	return nil
 }
 
 func (c *CastingMachine) SetV(v float32) {
-	c.CoolerConfig.V = int64(v * 1000 / 60)
-	OneSliceDuration = time.Millisecond * time.Duration(1000*float32(model.ZStep)/float32(c.CoolerConfig.V)) // 10 / c.v
+	c.setV(v)
 	log.WithFields(log.Fields{
 		"V":                c.CoolerConfig.V,
 		"oneSliceDuration": OneSliceDuration.Milliseconds(),
 	}).Info("设置拉速")
 }
 
+func (c *CastingMachine) setV(v float32) {
+	c.CoolerConfig.V = int64(v * 1000 / 60)
+	OneSliceDuration = time.Millisecond * time.Duration(1000*float32(model.ZStep)/float32(c.CoolerConfig.V)) // 10 / c.v
+}
+
 // 冷却器参数单独设置
 func (c *CastingMachine) SetStartTemperature(startTemperature float32) {
 	c.CoolerConfig.StartTemperature = startTemperature
@@ -131,6 +164,25 @@ func (c *CastingMachine) SetWideSurfaceOut(wideSurfaceOut float32) {
 	c.CoolerConfig.WideSurfaceOut = wideSurfaceOut
 }
 
+func (c *CastingMachine) SetNarrowWaterVolume(narrowWaterVolume float32) {
+	c.CoolerConfig.NarrowWaterVolume = narrowWaterVolume
+}
+
+func (c *CastingMachine) SetWideWaterVolume(wideWaterVolume float32) {
+	c.CoolerConfig.WideWaterVolume = wideWaterVolume
+}
+
+// 同时设置结晶器宽面、窄面的进出水温度和水量
+func (c *CastingMachine) SetMd(md model.Md) {
+	c.SetNarrowSurfaceIn(md.NarrowSurfaceIn)
+	c.SetNarrowSurfaceOut(md.NarrowSurfaceOut)
+	c.SetNarrowWaterVolume(md.NarrowSurfaceVolume)
+	c.SetWideSurfaceIn(md.WideSurfaceIn)
+	c.SetWideSurfaceOut(md.WideSurfaceOut)
+	c.SetWideWaterVolume(md.WideSurfaceVolume)
+	log.WithField("md", md).Info("设置结晶器冷却参数")
+}
+
 // 获取在那个冷却区
 func (c *CastingMachine) WhichZone(z int) int {
 	pos := float32(z * model.ZStep)
@@ -174,3 +226,88 @@ func (c *CastingMachine) GetElectromagneticStirringFactor(z int) float32 {
 	}
 	return 1.0
 }
+
+// 复制铸机配置，二冷水量单独复制，修改副本的水量不影响原铸机
+func (c *CastingMachine) clone() *CastingMachine {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := &CastingMachine{
+		Coordinate:   c.Coordinate,
+		CoolerConfig: c.CoolerConfig,
+		Segments:     c.Segments,
+		MoldWidth:    c.MoldWidth,
+		Geometry:     c.Geometry,
+	}
+	waterCfg := c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	m.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = append([]model.SecondaryCoolingWaterSection(nil), waterCfg...)
+	return m
+}
+
+var ErrZoneNotFound = errors.New("冷却区不存在")
+
+// 修改冷却区的二冷水量，在下一次计算换热系数时生效
+func (c *CastingMachine) SetSecondaryCoolingWater(changes []model.SecondaryCoolingChange) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	zones := len(c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg)
+	for _, change := range changes {
+		if change.Zone < 1 || change.Zone > zones {
+			return fmt.Errorf("%w: %d", ErrZoneNotFound, change.Zone)
+		}
+	}
+	if c.pendingWater == nil {
+		c.pendingWater = make(map[int]model.SecondaryCoolingWaterSection)
+	}
+	for _, change := range changes {
+		c.pendingWater[change.Zone-1] = change.Section
+	}
+	log.WithField("changes", changes).Info("修改二冷水量")
+	return nil
+}
+
+// 修改冷却区的内弧水量，窄面和幅切水量按比例调整，在下一次计算换热系数时生效
+func (c *CastingMachine) SetZoneWaterVolume(zone int, volume float32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waterCfg := c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	if zone < 1 || zone > len(waterCfg) {
+		return fmt.Errorf("%w: %d", ErrZoneNotFound, zone)
+	}
+	section, ok := c.pendingWater[zone-1]
+	if !ok {
+		section = waterCfg[zone-1]
+	}
+	scaleZoneVolume(&section, section.InnerArcWaterVolume, volume)
+	if c.pendingWater == nil {
+		c.pendingWater = make(map[int]model.SecondaryCoolingWaterSection)
+	}
+	c.pendingWater[zone-1] = section
+	return nil
+}
+
+// 冷却区的内弧水量，有待生效的修改时返回修改后的水量
+func (c *CastingMachine) GetZoneWaterVolume(zone int) (float32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waterCfg := c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	if zone < 1 || zone > len(waterCfg) {
+		return 0, fmt.Errorf("%w: %d", ErrZoneNotFound, zone)
+	}
+	if section, ok := c.pendingWater[zone-1]; ok {
+		return section.InnerArcWaterVolume, nil
+	}
+	return waterCfg[zone-1].InnerArcWaterVolume, nil
+}
+
+// 应用待生效的二冷水量，在计算协程中调用
+func (c *CastingMachine) applyPendingWater() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waterCfg := c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	for zone, section := range c.pendingWater {
+		if zone < len(waterCfg) {
+			waterCfg[zone] = section
+		}
+	}
+	c.pendingWater = nil
+}
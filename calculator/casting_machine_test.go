@@ -0,0 +1,78 @@
+package calculator
+
+import (
+	"errors"
+	"lz/model"
+	"testing"
+)
+
+func TestSetSecondaryCoolingWater(t *testing.T) {
+	c := NewCastingMachine()
+	c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{
+		{InnerArcWaterVolume: 100},
+		{InnerArcWaterVolume: 80},
+	}
+	err := c.SetSecondaryCoolingWater([]model.SecondaryCoolingChange{
+		{Zone: 2, Section: model.SecondaryCoolingWaterSection{InnerArcWaterVolume: 60, NarrowSideWaterVolume: 20, SprayWaterTemperature: 25}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waterCfg := c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	if waterCfg[1].InnerArcWaterVolume != 80 {
+		t.Fatal("水量应在下一次计算换热系数时才生效")
+	}
+	c.applyPendingWater()
+	if waterCfg[1].InnerArcWaterVolume != 60 || waterCfg[1].SprayWaterTemperature != 25 || waterCfg[0].InnerArcWaterVolume != 100 {
+		t.Fatalf("二冷水量不正确: %+v", waterCfg)
+	}
+
+	err = c.SetSecondaryCoolingWater([]model.SecondaryCoolingChange{{Zone: 1}, {Zone: 3}})
+	if !errors.Is(err, ErrZoneNotFound) {
+		t.Fatal("不存在的冷却区应该被拒绝:", err)
+	}
+	c.applyPendingWater()
+	if waterCfg[0].InnerArcWaterVolume != 100 {
+		t.Fatal("出错时不应修改任何冷却区")
+	}
+}
+
+func TestSetMd(t *testing.T) {
+	c := NewCastingMachine()
+	c.SetMd(model.Md{
+		NarrowSurfaceIn:     30,
+		NarrowSurfaceOut:    38,
+		NarrowSurfaceVolume: 560,
+		WideSurfaceIn:       31,
+		WideSurfaceOut:      39,
+		WideSurfaceVolume:   3100,
+	})
+	cfg := c.CoolerConfig
+	if cfg.NarrowWaterVolume != 560 || cfg.WideWaterVolume != 3100 {
+		t.Fatalf("结晶器水量不正确: %+v", cfg)
+	}
+	if cfg.NarrowSurfaceIn != 30 || cfg.NarrowSurfaceOut != 38 || cfg.WideSurfaceIn != 31 || cfg.WideSurfaceOut != 39 {
+		t.Fatalf("结晶器水温不正确: %+v", cfg)
+	}
+}
+
+func TestSetZoneWaterVolume(t *testing.T) {
+	c := NewCastingMachine()
+	c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{
+		{InnerArcWaterVolume: 80, NarrowSideWaterVolume: 40},
+	}
+	if err := c.SetZoneWaterVolume(1, 60); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.GetZoneWaterVolume(1); err != nil || v != 60 {
+		t.Fatal("应返回待生效的水量:", v, err)
+	}
+	c.applyPendingWater()
+	section := c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg[0]
+	if section.InnerArcWaterVolume != 60 || section.NarrowSideWaterVolume != 30 {
+		t.Fatalf("窄面水量应按比例调整: %+v", section)
+	}
+	if err := c.SetZoneWaterVolume(2, 60); !errors.Is(err, ErrZoneNotFound) {
+		t.Fatal("不存在的冷却区应该被拒绝:", err)
+	}
+}
@@ -22,6 +22,10 @@ type TemperatureFieldData struct {
 	IsFull bool   `json:"is_full"` // 切片是否充满铸机
 	IsTail bool   `json:"is_tail"` // 是否拉尾坯
 	Sides  *Sides `json:"sides"`
+
+	Widths          []float32        `json:"widths"`           // 每隔 StepZ 个切片的宽度
+	WidthTransition *WidthTransition `json:"width_transition"` // 调宽过渡区
+	Geometry        []GeometryPoint  `json:"geometry"`         // 每隔 StepZ 个切片的中心线坐标
 }
 
 type Sides struct {
@@ -93,6 +97,8 @@ func initPushData(up, arc, down float32) {
 }
 
 func (c *calculatorWithArrDeque) BuildData() *TemperatureFieldData {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
 	temperatureData := &TemperatureFieldData{
 		Sides: sides,
 	}
@@ -144,6 +150,14 @@ func (c *calculatorWithArrDeque) BuildData() *TemperatureFieldData {
 	temperatureData.End = c.end
 	temperatureData.IsFull = c.Field.IsFull()
 	temperatureData.IsTail = c.isTail
+	temperatureData.Widths = make([]float32, 0, c.metas.size()/StepZ+1)
+	for z := 0; z < c.metas.size(); z += StepZ {
+		temperatureData.Widths = append(temperatureData.Widths, c.metas.get(z).Width)
+	}
+	temperatureData.WidthTransition = c.widthTransition()
+	if c.castingMachine.Geometry != nil {
+		temperatureData.Geometry = c.castingMachine.Geometry.Sample(StepZ)
+	}
 	log.Debug("build data cost: ", time.Since(startTime))
 	return temperatureData
 }
@@ -159,6 +173,8 @@ type SliceInfo struct {
 }
 
 func (c *calculatorWithArrDeque) GenerateSLiceInfo(index int) *SliceInfo {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
 	return c.buildSliceGenerateData(index)
 }
 
@@ -263,6 +279,8 @@ type VerticalSliceData1 struct {
 
 // 纵切面曲线
 func (c *calculatorWithArrDeque) GenerateVerticalSlice1Data() *VerticalSliceData1 {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
 	var index int
 	res := &VerticalSliceData1{
 		CenterOuter: make([][2]float32, 0),
@@ -304,6 +322,8 @@ type Join struct {
 
 // 纵切面云图
 func (c *calculatorWithArrDeque) GenerateVerticalSlice2Data(reqData model.VerticalReqData) *VerticalSliceData2 {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
 	solidTemp := c.steel1.SolidPhaseTemperature
 	liquidTemp := c.steel1.LiquidPhaseTemperature
 	index := reqData.Index
@@ -389,6 +409,8 @@ type ShellCurvesData struct {
 }
 
 func (c *calculatorWithArrDeque) GenerateShellCurves() *ShellCurvesData {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
 	solidTemp := c.steel1.SolidPhaseTemperature
 	liquidTemp := c.steel1.LiquidPhaseTemperature
 	var VerticalSolidThickness, VerticalLiquidThickness, HorizontalSolidThickness, HorizontalLiquidThickness float32
@@ -0,0 +1,170 @@
+package calculator
+
+import (
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"time"
+)
+
+// 单个冷却区的一次控制动作
+type CoolingAction struct {
+	ZoneName  string  `json:"zone_name"`
+	Measured  float32 `json:"measured"` // 冷却区宽面中心平均表面温度
+	Target    float32 `json:"target"`
+	Error     float32 `json:"error"`
+	OldVolume float32 `json:"old_volume"`
+	NewVolume float32 `json:"new_volume"`
+	Saturated bool    `json:"saturated"` // 水量达到上下限或单次调整量限制
+}
+
+// 一个控制周期的全部动作
+type DynamicCoolingData struct {
+	Enabled bool            `json:"enabled"`
+	Cycle   int             `json:"cycle"`
+	Actions []CoolingAction `json:"actions"`
+}
+
+// PID 控制器状态，每个冷却区一组
+type coolingController struct {
+	elapsed  time.Duration
+	cycle    int
+	integral []float32
+	preError []float32
+	actions  []CoolingAction
+}
+
+// 设置动态二冷控制配置，重新设置后清空积分项
+func (c *calculatorWithArrDeque) SetDynamicCoolingCfg(cfg model.DynamicCoolingCfg) {
+	c.mu.Lock()
+	c.dynamicCoolingCfg = cfg
+	c.controller = coolingController{}
+	if cfg.Enabled {
+		c.coolingMode = model.CoolingModeDynamic
+	} else if c.coolingMode == model.CoolingModeDynamic {
+		c.coolingMode = model.CoolingModeManual
+	}
+	c.mu.Unlock()
+	log.WithField("cfg", cfg).Info("动态二冷控制配置已更新")
+}
+
+// 获取最近一个控制周期的动作
+func (c *calculatorWithArrDeque) GenerateDynamicCoolingData() *DynamicCoolingData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &DynamicCoolingData{
+		Enabled: c.dynamicCoolingCfg.Enabled,
+		Cycle:   c.controller.cycle,
+		Actions: append([]CoolingAction{}, c.controller.actions...),
+	}
+}
+
+// 在计算循环中调用，水表模式下拉速变化时查表，动态控制模式下每经过一个控制周期调整一次二冷水量
+// 与二冷区换热系数的计算在同一个协程中，修改水量不会影响正在进行的计算
+// 二冷水量还会被离线优化等其他协程读取，读写时持有铸机的锁，加锁顺序为先 c.mu 后 castingMachine.mu
+func (c *calculatorWithArrDeque) controlCooling(dt time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.applyWaterTables()
+	cfg := c.dynamicCoolingCfg
+	if !cfg.Enabled || c.steel1 == nil {
+		return
+	}
+	ctl := &c.controller
+	ctl.elapsed += dt
+	period := time.Duration(cfg.Period * float32(time.Second))
+	if ctl.elapsed < period {
+		return
+	}
+	seconds := float32(ctl.elapsed.Seconds())
+	ctl.elapsed = 0
+
+	targets := cfg.Targets[c.steel1.Number]
+	coolingZoneCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg
+	if len(ctl.integral) != len(coolingZoneCfg) {
+		ctl.integral = make([]float32, len(coolingZoneCfg))
+		ctl.preError = make([]float32, len(coolingZoneCfg))
+	}
+	measured := c.zoneSurfaceTemperatures(len(coolingZoneCfg))
+	c.castingMachine.mu.Lock()
+	defer c.castingMachine.mu.Unlock()
+	c.castingMachine.CoolerConfig.TargetTemperature = targets
+	waterCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	actions := make([]CoolingAction, 0, len(coolingZoneCfg))
+	for i, zone := range coolingZoneCfg {
+		target, ok := targets[zone.ZoneName]
+		if !ok || measured[i] == 0 || i >= len(waterCfg) {
+			continue
+		}
+		// 表面温度高于目标时增加水量
+		e := measured[i] - target
+		derivative := (e - ctl.preError[i]) / seconds
+		ctl.preError[i] = e
+		delta := cfg.Kp*e + cfg.Ki*(ctl.integral[i]+e*seconds) + cfg.Kd*derivative
+		saturated := false
+		if cfg.MaxStep > 0 && delta > cfg.MaxStep {
+			delta, saturated = cfg.MaxStep, true
+		} else if cfg.MaxStep > 0 && delta < -cfg.MaxStep {
+			delta, saturated = -cfg.MaxStep, true
+		}
+		old := waterCfg[i].InnerArcWaterVolume
+		volume := old + delta
+		if volume > cfg.MaxVolume {
+			volume, saturated = cfg.MaxVolume, true
+		} else if volume < cfg.MinVolume {
+			volume, saturated = cfg.MinVolume, true
+		}
+		// 饱和时不再累积积分，避免积分饱和
+		if !saturated {
+			ctl.integral[i] += e * seconds
+		}
+		scaleZoneVolume(&waterCfg[i], old, volume)
+		actions = append(actions, CoolingAction{
+			ZoneName:  zone.ZoneName,
+			Measured:  measured[i],
+			Target:    target,
+			Error:     e,
+			OldVolume: old,
+			NewVolume: volume,
+			Saturated: saturated,
+		})
+	}
+	ctl.cycle++
+	ctl.actions = actions
+	log.WithField("actions", actions).Debug("动态二冷控制")
+}
+
+// 按内弧水量的变化比例同时调整窄面和幅切水量
+func scaleZoneVolume(section *model.SecondaryCoolingWaterSection, old, volume float32) {
+	section.InnerArcWaterVolume = volume
+	if old <= 0 {
+		return
+	}
+	ratio := volume / old
+	section.NarrowSideWaterVolume *= ratio
+	section.Fuqie1Volume *= ratio
+	section.Fuqie2Volume *= ratio
+}
+
+// 每个冷却区宽面中心的平均表面温度，冷却区内没有切片时为 0
+func (c *calculatorWithArrDeque) zoneSurfaceTemperatures(zones int) []float32 {
+	sum := make([]float32, zones)
+	count := make([]int, zones)
+	for z := 0; z < c.Field.Size(); z++ {
+		zone := c.castingMachine.WhichZone(z)
+		if zone <= Zone0 || zone > zones {
+			continue
+		}
+		slice := c.Field.GetSlice(z)
+		if slice[0][0] == -1 {
+			continue
+		}
+		sum[zone-1] += slice[Width/YStep-1][0]
+		count[zone-1]++
+	}
+	for i := range sum {
+		if count[i] > 0 {
+			sum[i] /= float32(count[i])
+		}
+	}
+	return sum
+}
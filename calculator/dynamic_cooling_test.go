@@ -0,0 +1,99 @@
+package calculator
+
+import (
+	"lz/model"
+	"testing"
+	"time"
+)
+
+func TestControlCooling(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	c.steel1 = &Steel{Number: 1, Parameter: &Parameter{}}
+	c.castingMachine.Coordinate.MdLength = 50
+	c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg = []model.CoolingZone{
+		{ZoneName: "1 Subarea", EndDistance: 120},
+		{ZoneName: "2 Subarea", EndDistance: 200},
+	}
+	c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{
+		{InnerArcWaterVolume: 100, NarrowSideWaterVolume: 50},
+		{InnerArcWaterVolume: 100},
+	}
+	for z := ZLength/ZStep - 1; z >= 0; z-- {
+		c.thermalField.AddFirst(1000)
+	}
+	c.SetDynamicCoolingCfg(model.DynamicCoolingCfg{
+		Enabled: true,
+		Targets: map[int]map[string]float32{
+			1: {"1 Subarea": 980, "2 Subarea": 1010},
+		},
+		Kp:        1,
+		MinVolume: 10,
+		MaxVolume: 200,
+		MaxStep:   15,
+		Period:    1,
+	})
+
+	// 未到控制周期时不调整
+	c.controlCooling(500 * time.Millisecond)
+	if data := c.GenerateDynamicCoolingData(); data.Cycle != 0 {
+		t.Fatal("未到控制周期不应调整水量")
+	}
+	c.controlCooling(500 * time.Millisecond)
+	data := c.GenerateDynamicCoolingData()
+	if data.Cycle != 1 || len(data.Actions) != 2 {
+		t.Fatalf("控制动作不正确: %+v", data)
+	}
+	waterCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	// 1 区高于目标 20 度，调整量被限制为 15
+	if waterCfg[0].InnerArcWaterVolume != 115 || !data.Actions[0].Saturated {
+		t.Fatalf("1 区水量应增加到 115: %+v", data.Actions[0])
+	}
+	if waterCfg[0].NarrowSideWaterVolume != 57.5 {
+		t.Fatalf("窄面水量应按比例调整: %v", waterCfg[0].NarrowSideWaterVolume)
+	}
+	// 2 区低于目标 10 度，减少水量
+	if waterCfg[1].InnerArcWaterVolume != 90 || data.Actions[1].Saturated {
+		t.Fatalf("2 区水量应减少到 90: %+v", data.Actions[1])
+	}
+	if c.castingMachine.CoolerConfig.TargetTemperature["1 Subarea"] != 980 {
+		t.Fatal("应按钢种设置目标温度")
+	}
+}
+
+// 动态控制修改二冷水量时，离线优化可以同时复制铸机配置
+func TestControlCoolingConcurrentClone(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	defer c.Close()
+	c.steel1 = &Steel{Number: 1, Parameter: &Parameter{}}
+	c.castingMachine.Coordinate.MdLength = 50
+	c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg = []model.CoolingZone{{ZoneName: "1 Subarea", EndDistance: 200}}
+	c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{{InnerArcWaterVolume: 100}}
+	for z := ZLength/ZStep - 1; z >= 0; z-- {
+		c.thermalField.AddFirst(1000)
+	}
+	c.SetDynamicCoolingCfg(model.DynamicCoolingCfg{
+		Enabled:   true,
+		Targets:   map[int]map[string]float32{1: {"1 Subarea": 980}},
+		Kp:        1,
+		MinVolume: 10,
+		MaxVolume: 200,
+		Period:    1,
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			c.castingMachine.clone()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		c.controlCooling(time.Second)
+	}
+	<-done
+}
@@ -9,6 +9,8 @@ import (
 type executor interface {
 	run(c *calculatorWithArrDeque)
 	dispatchTask(deltaT float32, first, last int) time.Duration
+	// 停止 master 和 worker 协程，停止后不能再分配任务
+	stop()
 }
 
 // 基于切片任务分配 - 使用中
@@ -19,6 +21,8 @@ type executorBaseOnSlice struct {
 	doneSoFar chan struct{}
 	finish    chan struct{}
 	start     chan task
+	quit      chan struct{}
+	stopOnce  sync.Once
 }
 
 type task struct {
@@ -35,6 +39,7 @@ func newExecutorBaseOnSlice(workers int) *executorBaseOnSlice {
 		doneSoFar: make(chan struct{}, 50),
 		finish:    make(chan struct{}, 1),
 		start:     make(chan task, 1),
+		quit:      make(chan struct{}),
 	}
 
 	return e
@@ -50,6 +55,10 @@ func (e *executorBaseOnSlice) dispatchTask(deltaT float32, first, last int) time
 	return time.Since(start)
 }
 
+func (e *executorBaseOnSlice) stop() {
+	e.stopOnce.Do(func() { close(e.quit) })
+}
+
 func (e *executorBaseOnSlice) run(c *calculatorWithArrDeque) {
 	total := 0
 	totalTasks := 0
@@ -57,6 +66,8 @@ func (e *executorBaseOnSlice) run(c *calculatorWithArrDeque) {
 	go func() {
 		for {
 			select {
+			case <-e.quit:
+				return
 			case tasks := <-e.start:
 				//fmt.Println("master 分配任务: ", tasks)
 				if tasks.end-tasks.start == 0 {
@@ -126,6 +137,8 @@ func (e *executorBaseOnSlice) run(c *calculatorWithArrDeque) {
 		go func(i int) {
 			for {
 				select {
+				case <-e.quit:
+					return
 				case t := <-e.dispatchChan:
 					//fmt.Println("worker ", i, "获取到任务: ", t)
 					e.traverseSpirally(t, c)
@@ -147,6 +160,8 @@ type executorBaseOnBlock struct {
 	dispatchChan chan task
 	finishChan   chan struct{}
 	f            []func(t task, c *calculatorWithArrDeque)
+	quit         chan struct{}
+	stopOnce     sync.Once
 }
 
 func newExecutorBaseOnBlock(edgeWidth int) *executorBaseOnBlock {
@@ -163,6 +178,7 @@ func newExecutorBaseOnBlock(edgeWidth int) *executorBaseOnBlock {
 		dispatchChan: make(chan task, 1),
 		finishChan:   make(chan struct{}, 10),
 		f:            make([]func(t task, c *calculatorWithArrDeque), 4),
+		quit:         make(chan struct{}),
 	}
 
 	e.step = 1
@@ -183,6 +199,8 @@ func (e *executorBaseOnBlock) run(c *calculatorWithArrDeque) {
 	go func() {
 		for {
 			select {
+			case <-e.quit:
+				return
 			case t := <-e.dispatchChan:
 				e.wg.Add(4)
 				for i := 0; i < 4; i++ {
@@ -218,3 +236,7 @@ func (e *executorBaseOnBlock) dispatchTask(deltaT float32, first, last int) time
 	}
 	return time.Since(start)
 }
+
+func (e *executorBaseOnBlock) stop() {
+	e.stopOnce.Do(func() { close(e.quit) })
+}
@@ -0,0 +1,49 @@
+package calculator
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCalculatorClose(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	before := runtime.NumGoroutine()
+	c := NewCalculatorWithArrDeque(nil)
+	c.e.dispatchTask(0.1, 0, 0)
+	if runtime.NumGoroutine() <= before {
+		t.Fatal("应启动计算协程")
+	}
+	c.Close()
+	c.Close()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("释放后计算协程应全部退出: %d > %d", n, before)
+	}
+	if c.Field != nil || c.thermalField != nil {
+		t.Fatal("释放后应不再持有温度场")
+	}
+	// 已经释放的计算器不再计算
+	c.Run()
+}
+
+func TestPushSignalAfterStop(t *testing.T) {
+	calcHub := NewCalcHub()
+	calcHub.StartSignal()
+	calcHub.StopSignal()
+	done := make(chan struct{})
+	go func() {
+		calcHub.PushSignal()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("停止计算后推送不应阻塞")
+	}
+}
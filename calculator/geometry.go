@@ -0,0 +1,100 @@
+package calculator
+
+import (
+	"lz/model"
+	"math"
+)
+
+// 铸流中心线上一点的机器坐标，原点为弯月面中心
+// X 为水平出坯方向，Y 为竖直向上，Z 为宽面方向（中心线上恒为 0），单位mm
+type GeometryPoint struct {
+	Distance float32 `json:"distance"` // 距弯月面的距离
+	X        float32 `json:"x"`
+	Y        float32 `json:"y"`
+	Z        float32 `json:"z"`
+	Angle    float32 `json:"angle"` // 与竖直方向的夹角，单位度
+}
+
+// 铸流中心线几何，按切片下标预先计算好每个切片的坐标
+type Geometry struct {
+	points []GeometryPoint
+}
+
+// 根据铸机尺寸配置计算中心线几何，曲率沿中心线积分得到弯曲角和坐标
+func NewGeometry(coordinate model.Coordinate) *Geometry {
+	zones := coordinate.BendingZones
+	if len(zones) == 0 && coordinate.R > 0 {
+		zones = []model.BendingZone{{
+			StartDistance: coordinate.CenterStartDistance,
+			EndDistance:   coordinate.CenterEndDistance,
+			StartRadius:   coordinate.R,
+			EndRadius:     coordinate.R,
+		}}
+	}
+	n := coordinate.ZLength / ZStep
+	g := &Geometry{points: make([]GeometryPoint, n)}
+	step := float64(ZStep)
+	var x, y, theta float64
+	for z := 0; z < n; z++ {
+		g.points[z] = GeometryPoint{
+			Distance: float32(z * ZStep),
+			X:        float32(x),
+			Y:        float32(y),
+			Angle:    float32(theta * 180 / math.Pi),
+		}
+		// 取步长中点的曲率推进到下一个切片
+		k := curvature(zones, float32(z*ZStep)+float32(ZStep)/2)
+		mid := theta + k*step/2
+		x += math.Sin(mid) * step
+		y -= math.Cos(mid) * step
+		theta += k * step
+	}
+	return g
+}
+
+// 距弯月面 distance 处的曲率
+func curvature(zones []model.BendingZone, distance float32) float64 {
+	for _, zone := range zones {
+		if distance < zone.StartDistance || distance >= zone.EndDistance {
+			continue
+		}
+		ratio := float64((distance - zone.StartDistance) / (zone.EndDistance - zone.StartDistance))
+		return inverse(zone.StartRadius) + (inverse(zone.EndRadius)-inverse(zone.StartRadius))*ratio
+	}
+	return 0
+}
+
+func inverse(r float32) float64 {
+	if r == 0 {
+		return 0
+	}
+	return 1 / float64(r)
+}
+
+// 获取下标 z 处切片的坐标，超出范围时取最近的切片
+func (g *Geometry) At(z int) GeometryPoint {
+	if len(g.points) == 0 {
+		return GeometryPoint{}
+	}
+	if z < 0 {
+		z = 0
+	}
+	if z >= len(g.points) {
+		z = len(g.points) - 1
+	}
+	return g.points[z]
+}
+
+// 获取距弯月面 distance 处的坐标
+func (g *Geometry) AtDistance(distance float32) GeometryPoint {
+	return g.At(int(distance) / ZStep)
+}
+
+// 每隔 step 个切片取一个点
+func (g *Geometry) Sample(step int) []GeometryPoint {
+	res := make([]GeometryPoint, 0, len(g.points)/step+1)
+	for z := 0; z < len(g.points); z += step {
+		res = append(res, g.points[z])
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+package calculator
+
+import (
+	"lz/model"
+	"math"
+	"testing"
+)
+
+func TestGeometrySingleArc(t *testing.T) {
+	r := float32(9000)
+	coordinate := model.Coordinate{
+		R:                   r,
+		CenterStartDistance: 1000,
+		CenterEndDistance:   1000 + r*math.Pi/2,
+		ZLength:             20000,
+	}
+	g := NewGeometry(coordinate)
+
+	p := g.AtDistance(500)
+	if p.X != 0 || p.Y != -500 || p.Angle != 0 {
+		t.Fatalf("直线段坐标错误: %+v", p)
+	}
+	// 圆弧结束后应为水平方向，且竖直方向下降 1000 + R
+	p = g.AtDistance(19000)
+	if math.Abs(float64(p.Angle-90)) > 0.1 {
+		t.Fatalf("弧形段结束后角度应为 90 度: %+v", p)
+	}
+	if math.Abs(float64(p.Y+1000+r)) > float64(ZStep) {
+		t.Fatalf("水平段高度错误: %+v", p)
+	}
+}
+
+func TestGeometryBendingZones(t *testing.T) {
+	coordinate := model.Coordinate{
+		R:       9000,
+		ZLength: 10000,
+		BendingZones: []model.BendingZone{
+			{StartDistance: 1000, EndDistance: 3000, StartRadius: 0, EndRadius: 9000},
+			{StartDistance: 3000, EndDistance: 5000, StartRadius: 9000, EndRadius: 9000},
+		},
+	}
+	g := NewGeometry(coordinate)
+	// 渐变弯曲区的角度为匀曲率的一半
+	p := g.AtDistance(3000)
+	want := 2000.0 / 9000 / 2 * 180 / math.Pi
+	if math.Abs(float64(p.Angle)-want) > 0.05 {
+		t.Fatalf("弯曲区结束时角度应为 %.2f: %+v", want, p)
+	}
+	pre := g.At(0)
+	for z := 1; z < len(g.points); z++ {
+		if g.At(z).Angle < pre.Angle {
+			t.Fatalf("弯曲角应单调增加: %+v %+v", pre, g.At(z))
+		}
+		pre = g.At(z)
+	}
+}
@@ -0,0 +1,44 @@
+package calculator
+
+import (
+	"errors"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"sync/atomic"
+)
+
+// 铸坯尺寸 ZLength、Length、Width 为包级变量，所有计算器共用
+// 存在没有释放的计算器时不能修改，否则这些计算器会按新的尺寸访问原来的温度场
+var ErrGridInUse = errors.New("铸坯断面与已有的计算器不一致，需要先删除已有的计算器")
+
+var (
+	gridMu          sync.Mutex   // 修改铸坯尺寸并新建计算器时持有
+	liveCalculators atomic.Int64 // 没有释放的计算器数量，新建时加一，Close 时减一
+)
+
+// 按铸坯尺寸新建计算器，length 和 width 为四分之一横截面的尺寸
+// 已有计算器使用不同的尺寸时返回 ErrGridInUse
+func NewCalculatorWithGrid(zLength, length, width int) (Calculator, error) {
+	gridMu.Lock()
+	defer gridMu.Unlock()
+	if err := checkGrid(zLength, length, width); err != nil {
+		return nil, err
+	}
+	ZLength, Length, Width = zLength, length, width
+	log.Info("ZLength:", ZLength, " ,Length:", Length, " ,Width:", Width)
+	return NewCalculatorWithArrDeque(nil), nil
+}
+
+// 检查铸坯尺寸是否与已有的计算器一致，修改已有计算器的计算环境前调用
+func CheckGrid(zLength, length, width int) error {
+	gridMu.Lock()
+	defer gridMu.Unlock()
+	return checkGrid(zLength, length, width)
+}
+
+func checkGrid(zLength, length, width int) error {
+	if liveCalculators.Load() > 0 && (zLength != ZLength || length != Length || width != Width) {
+		return ErrGridInUse
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewCalculatorWithGrid(t *testing.T) {
+	// 不受其他测试中没有释放的计算器影响
+	live := liveCalculators.Swap(0)
+	defer liveCalculators.Add(live)
+
+	c, err := NewCalculatorWithGrid(200, 50, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ZLength != 200 || Length != 50 || Width != 20 {
+		t.Fatalf("应设置铸坯尺寸: %d %d %d", ZLength, Length, Width)
+	}
+	if _, err = NewCalculatorWithGrid(200, 60, 20); !errors.Is(err, ErrGridInUse) {
+		t.Fatalf("已有计算器时不应修改铸坯尺寸: %v", err)
+	}
+	if err = CheckGrid(200, 50, 20); err != nil {
+		t.Fatal("尺寸一致时应通过检查:", err)
+	}
+	if err = CheckGrid(210, 50, 20); !errors.Is(err, ErrGridInUse) {
+		t.Fatalf("尺寸不一致时应不通过检查: %v", err)
+	}
+	c.Close()
+	c, err = NewCalculatorWithGrid(200, 60, 20)
+	if err != nil {
+		t.Fatal("释放后应可以修改铸坯尺寸:", err)
+	}
+	c.Close()
+	if Length != 60 {
+		t.Fatalf("应修改铸坯尺寸: %d", Length)
+	}
+}
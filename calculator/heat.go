@@ -0,0 +1,233 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"time"
+)
+
+const (
+	HeatSampleInterval = 10 * time.Second // 炉次统计的采样间隔，计算时间
+	MaxHeats           = 100              // 最多保存的炉次数
+)
+
+var (
+	ErrHeatOpened      = errors.New("已有炉次正在浇铸")
+	ErrHeatNotOpened   = errors.New("没有正在浇铸的炉次")
+	ErrInvalidHeatType = errors.New("炉次事件类型不存在")
+)
+
+// 报警类型
+const (
+	AlarmCraterEnd = "crater_end" // 液芯末端超出铸机
+	AlarmNozzle    = "nozzle"     // 严重喷嘴故障
+	AlarmMeniscus  = "meniscus"   // 结晶器液面不稳定
+)
+
+// 炉次浇铸过程中的报警
+type HeatAlarm struct {
+	Time    float32 `json:"time"` // 计算时间 s
+	Type    string  `json:"type"`
+	Message string  `json:"message"`
+}
+
+// 单个炉次的统计结果，温度为采样期间该炉次切片的平均值
+type HeatSummary struct {
+	HeatID                   string             `json:"heat_id"`
+	Grade                    string             `json:"grade"`
+	OpenTime                 float32            `json:"open_time"`  // 计算时间 s
+	CloseTime                float32            `json:"close_time"` // 计算时间 s，-1 表示正在浇铸
+	WideSurfaceTemperature   float32            `json:"wide_surface_temperature"`
+	NarrowSurfaceTemperature float32            `json:"narrow_surface_temperature"`
+	ZoneSurfaceTemperature   map[string]float32 `json:"zone_surface_temperature"` // 冷却区名称 -> 宽面中心平均表面温度
+	MaxCraterEnd             float32            `json:"max_crater_end"`           // 该炉次切片所在的最远液芯末端位置，-1 表示没有
+	Alarms                   []HeatAlarm        `json:"alarms"`
+}
+
+// 炉次报告
+type HeatReport struct {
+	Current string        `json:"current"` // 正在浇铸的炉次，没有时为空
+	Heats   []HeatSummary `json:"heats"`
+}
+
+// 炉次统计的中间结果
+type heat struct {
+	summary     HeatSummary
+	wideSum     float32
+	narrowSum   float32
+	count       int
+	zoneSum     map[string]float32
+	zoneCount   map[string]int
+	alarmRaised map[string]bool // 已产生的报警，同一报警只记录一次
+}
+
+// 炉次跟踪状态
+type heatTracker struct {
+	elapsed     time.Duration
+	sinceSample time.Duration
+	current     *heat
+	heats       []*heat
+}
+
+// 处理开浇、停浇和换包事件
+func (c *calculatorWithArrDeque) HeatEvent(event model.HeatEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &c.heats
+	switch event.Type {
+	case model.HeatOpen:
+		if t.current != nil {
+			return fmt.Errorf("%w: %s", ErrHeatOpened, t.current.summary.HeatID)
+		}
+		t.open(event)
+	case model.HeatClose:
+		if t.current == nil || (event.HeatID != "" && event.HeatID != t.current.summary.HeatID) {
+			return fmt.Errorf("%w: %s", ErrHeatNotOpened, event.HeatID)
+		}
+		t.close()
+	case model.HeatLadleChange:
+		if t.current != nil {
+			t.close()
+		}
+		t.open(event)
+	default:
+		return ErrInvalidHeatType
+	}
+	if event.Temperature > 0 && event.Type != model.HeatClose {
+		c.castingMachine.ChangeLadle(model.LadleChange{Temperature: event.Temperature})
+	}
+	log.WithField("event", event).Info("炉次事件")
+	return nil
+}
+
+func (t *heatTracker) open(event model.HeatEvent) {
+	if len(t.heats) >= MaxHeats {
+		t.heats = t.heats[1:]
+	}
+	t.current = &heat{
+		summary: HeatSummary{
+			HeatID:       event.HeatID,
+			Grade:        event.Grade,
+			OpenTime:     float32(t.elapsed.Seconds()),
+			CloseTime:    -1,
+			MaxCraterEnd: -1,
+			Alarms:       make([]HeatAlarm, 0),
+		},
+		zoneSum:     make(map[string]float32),
+		zoneCount:   make(map[string]int),
+		alarmRaised: make(map[string]bool),
+	}
+	t.heats = append(t.heats, t.current)
+}
+
+func (t *heatTracker) close() {
+	t.current.summary.CloseTime = float32(t.elapsed.Seconds())
+	t.current = nil
+}
+
+func (t *heatTracker) find(heatID string) *heat {
+	for i := len(t.heats) - 1; i >= 0; i-- {
+		if t.heats[i].summary.HeatID == heatID {
+			return t.heats[i]
+		}
+	}
+	return nil
+}
+
+// 新切片所属的炉次，没有正在浇铸的炉次时为空
+func (c *calculatorWithArrDeque) currentHeatID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.heats.current == nil {
+		return ""
+	}
+	return c.heats.current.summary.HeatID
+}
+
+// 在计算循环中调用，每经过一个采样间隔按切片所属炉次统计表面温度、液芯末端和报警
+func (c *calculatorWithArrDeque) updateHeats(dt time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &c.heats
+	t.elapsed += dt
+	t.sinceSample += dt
+	if t.sinceSample < HeatSampleInterval || len(t.heats) == 0 || c.steel1 == nil {
+		return
+	}
+	t.sinceSample = 0
+	now := float32(t.elapsed.Seconds())
+	coolingZoneCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg
+	for z := 0; z < c.Field.Size(); z++ {
+		h := t.find(c.metas.get(z).HeatID)
+		slice := c.Field.GetSlice(z)
+		if h == nil || slice[0][0] == -1 {
+			continue
+		}
+		wide := slice[Width/YStep-1][0]
+		h.wideSum += wide
+		h.narrowSum += slice[0][Length/XStep-1]
+		h.count++
+		if zone := c.castingMachine.WhichZone(z); zone > Zone0 && zone <= len(coolingZoneCfg) {
+			name := coolingZoneCfg[zone-1].ZoneName
+			h.zoneSum[name] += wide
+			h.zoneCount[name]++
+		}
+	}
+
+	craterEnd := c.craterEnd()
+	if craterEnd >= 0 {
+		if h := t.find(c.metas.get(int(craterEnd) / ZStep).HeatID); h != nil && craterEnd > h.summary.MaxCraterEnd {
+			h.summary.MaxCraterEnd = craterEnd
+		}
+	} else if c.Field.IsFull() {
+		// 铸机末端的切片仍未完全凝固
+		if h := t.find(c.metas.get(c.Field.Size() - 1).HeatID); h != nil {
+			h.raise(now, AlarmCraterEnd, AlarmCraterEnd, "液芯末端超出铸机")
+		}
+	}
+	for _, failure := range c.nozzleReport().Failures {
+		if !failure.Critical {
+			continue
+		}
+		z := int(failure.StartDistance+failure.EndDistance) / 2 / ZStep
+		if h := t.find(c.metas.get(z).HeatID); h != nil {
+			key := fmt.Sprintf("%s-%s-%d", AlarmNozzle, failure.Side, failure.RollerNum)
+			h.raise(now, key, AlarmNozzle, failure.message())
+		}
+	}
+}
+
+func (h *heat) raise(now float32, key, typ, message string) {
+	if h.alarmRaised[key] {
+		return
+	}
+	h.alarmRaised[key] = true
+	h.summary.Alarms = append(h.summary.Alarms, HeatAlarm{Time: now, Type: typ, Message: message})
+	log.WithFields(log.Fields{"heat": h.summary.HeatID, "type": typ}).Warn(message)
+}
+
+// 生成炉次报告
+func (c *calculatorWithArrDeque) GenerateHeatReport() *HeatReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := &HeatReport{Heats: make([]HeatSummary, 0, len(c.heats.heats))}
+	if c.heats.current != nil {
+		report.Current = c.heats.current.summary.HeatID
+	}
+	for _, h := range c.heats.heats {
+		summary := h.summary
+		summary.Alarms = append([]HeatAlarm{}, h.summary.Alarms...)
+		summary.ZoneSurfaceTemperature = make(map[string]float32, len(h.zoneSum))
+		if h.count > 0 {
+			summary.WideSurfaceTemperature = h.wideSum / float32(h.count)
+			summary.NarrowSurfaceTemperature = h.narrowSum / float32(h.count)
+		}
+		for name, sum := range h.zoneSum {
+			summary.ZoneSurfaceTemperature[name] = sum / float32(h.zoneCount[name])
+		}
+		report.Heats = append(report.Heats, summary)
+	}
+	return report
+}
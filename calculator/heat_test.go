@@ -0,0 +1,77 @@
+package calculator
+
+import (
+	"errors"
+	"lz/model"
+	"testing"
+)
+
+func TestHeatEvent(t *testing.T) {
+	c := NewCalculatorWithArrDeque(nil)
+	if err := c.HeatEvent(model.HeatEvent{Type: model.HeatClose}); !errors.Is(err, ErrHeatNotOpened) {
+		t.Fatal("没有炉次时不能停浇:", err)
+	}
+	if err := c.HeatEvent(model.HeatEvent{Type: model.HeatOpen, HeatID: "A", Grade: "Q235"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.HeatEvent(model.HeatEvent{Type: model.HeatOpen, HeatID: "B"}); !errors.Is(err, ErrHeatOpened) {
+		t.Fatal("已有炉次时不能开浇:", err)
+	}
+	if c.currentHeatID() != "A" {
+		t.Fatal("当前炉次应为 A")
+	}
+	err := c.HeatEvent(model.HeatEvent{Type: model.HeatLadleChange, HeatID: "B", Temperature: 1560})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.currentHeatID() != "B" || c.castingMachine.CoolerConfig.StartTemperature != 1560 {
+		t.Fatal("换包后应开始新炉次并修改浇铸温度")
+	}
+	if err := c.HeatEvent(model.HeatEvent{Type: model.HeatClose, HeatID: "B"}); err != nil {
+		t.Fatal(err)
+	}
+	report := c.GenerateHeatReport()
+	if report.Current != "" || len(report.Heats) != 2 || report.Heats[0].CloseTime < 0 || report.Heats[1].CloseTime < 0 {
+		t.Fatalf("炉次报告不正确: %+v", report)
+	}
+}
+
+func TestUpdateHeats(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	c.steel1 = &Steel{SolidPhaseTemperature: 1400, Parameter: &Parameter{}}
+	c.castingMachine.Coordinate.MdLength = 50
+	c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg = []model.CoolingZone{
+		{ZoneName: "1 Subarea", EndDistance: 200},
+	}
+	c.HeatEvent(model.HeatEvent{Type: model.HeatOpen, HeatID: "A"})
+	c.HeatEvent(model.HeatEvent{Type: model.HeatLadleChange, HeatID: "B"})
+	// 远离弯月面的 10 个切片属于炉次 A，其余属于炉次 B
+	for z := ZLength/ZStep - 1; z >= 0; z-- {
+		heatID, temp := "B", float32(1100)
+		if z >= 10 {
+			heatID, temp = "A", 900
+		}
+		c.thermalField.AddFirst(temp)
+		c.metas.addFirst(sliceMeta{HeatID: heatID})
+	}
+
+	c.updateHeats(HeatSampleInterval / 2)
+	if report := c.GenerateHeatReport(); report.Heats[0].WideSurfaceTemperature != 0 {
+		t.Fatal("未到采样间隔不应统计")
+	}
+	c.updateHeats(HeatSampleInterval / 2)
+	report := c.GenerateHeatReport()
+	a, b := report.Heats[0], report.Heats[1]
+	if a.WideSurfaceTemperature != 900 || b.NarrowSurfaceTemperature != 1100 {
+		t.Fatalf("炉次平均表面温度不正确: %+v %+v", a, b)
+	}
+	if a.ZoneSurfaceTemperature["1 Subarea"] != 900 {
+		t.Fatalf("炉次冷却区表面温度不正确: %+v", a.ZoneSurfaceTemperature)
+	}
+	if b.MaxCraterEnd != 0 || a.MaxCraterEnd != -1 {
+		t.Fatalf("液芯末端位置不正确: %v %v", a.MaxCraterEnd, b.MaxCraterEnd)
+	}
+}
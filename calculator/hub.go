@@ -12,15 +12,31 @@ func NewCalcHub() *CalcHub {
 	}
 }
 
-// 温度场计算
+// 温度场计算，停止计算后没有接收方，不再等待
 func (ch *CalcHub) PushSignal() {
-	ch.PeriodCalcResult <- struct{}{}
+	select {
+	case ch.PeriodCalcResult <- struct{}{}:
+	case <-ch.Stop:
+	}
 }
 
 func (ch *CalcHub) StopSignal() {
 	close(ch.Stop)
 }
 
+// 没有开始计算或已经停止计算
+func (ch *CalcHub) Stopped() bool {
+	if ch.Stop == nil {
+		return true
+	}
+	select {
+	case <-ch.Stop:
+		return true
+	default:
+		return false
+	}
+}
+
 func (ch *CalcHub) StartSignal() {
 	ch.Stop = make(chan struct{})
 }
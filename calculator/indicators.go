@@ -0,0 +1,61 @@
+package calculator
+
+import (
+	"fmt"
+	"time"
+)
+
+// 当前存在的报警
+type Alarm struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// 关键指标，供 MES、看板等外部系统订阅
+type Indicators struct {
+	Time                   time.Time          `json:"time"`
+	MoldExitShell          ShellThickness     `json:"mold_exit_shell"` // 结晶器出口坯壳厚度
+	CraterEnd              float32            `json:"crater_end"`      // 液芯末端距弯月面的距离，-1 表示铸机内未完全凝固
+	ZoneSurfaceTemperature map[string]float32 `json:"zone_surface_temperature"`
+	Alarms                 []Alarm            `json:"alarms"`
+}
+
+// 生成关键指标
+func (c *calculatorWithArrDeque) GenerateIndicators() *Indicators {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
+	res := &Indicators{
+		Time:                   time.Now(),
+		CraterEnd:              -1,
+		ZoneSurfaceTemperature: make(map[string]float32),
+		Alarms:                 make([]Alarm, 0),
+	}
+	if c.steel1 == nil {
+		return res
+	}
+	mdEnd := float32(c.castingMachine.Coordinate.MdLength) - c.castingMachine.Coordinate.LevelHeight
+	res.MoldExitShell, _ = c.shellThicknessAt(mdEnd)
+	res.CraterEnd = c.craterEnd()
+	coolingZoneCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg
+	for i, temperature := range c.zoneSurfaceTemperatures(len(coolingZoneCfg)) {
+		if temperature > 0 {
+			res.ZoneSurfaceTemperature[coolingZoneCfg[i].ZoneName] = temperature
+		}
+	}
+
+	if res.CraterEnd < 0 && c.Field.IsFull() {
+		res.Alarms = append(res.Alarms, Alarm{Type: AlarmCraterEnd, Message: "液芯末端超出铸机"})
+	}
+	for _, failure := range c.nozzleReport().Failures {
+		if failure.Critical {
+			res.Alarms = append(res.Alarms, Alarm{Type: AlarmNozzle, Message: failure.message()})
+		}
+	}
+	if stability := c.castingMachine.GetMeniscusStability(); stability.State == MeniscusUnstable {
+		res.Alarms = append(res.Alarms, Alarm{
+			Type:    AlarmMeniscus,
+			Message: fmt.Sprintf("结晶器液面波动 %.1fmm", stability.MaxDeviation),
+		})
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+package calculator
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// 温度场计算的 Prometheus 指标，注册在默认注册表中，由 /metrics 暴露
+var (
+	stepDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "lz",
+		Subsystem: "solver",
+		Name:      "step_duration_seconds",
+		Help:      "在线计算一个时间步长消耗的墙钟时间",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	})
+	timeStepDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "lz",
+		Subsystem: "solver",
+		Name:      "time_step_calculation_seconds",
+		Help:      "计算时间步长 deltaT 消耗的墙钟时间",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 12),
+	})
+	deltaTSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "lz",
+		Subsystem: "solver",
+		Name:      "delta_t_seconds",
+		Help:      "在线计算的时间步长 deltaT",
+		Buckets:   []float64{0.01, 0.02, 0.05, 0.1, 0.15, 0.2, 0.3, 0.4, 0.6},
+	})
+	simulatedSeconds = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "lz",
+		Subsystem: "solver",
+		Name:      "simulated_seconds_total",
+		Help:      "在线计算累计推进的铸造时间",
+	})
+	pointsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "lz",
+		Subsystem: "solver",
+		Name:      "points_total",
+		Help:      "累计计算的节点数，包括离线优化",
+	})
+	realTimeFactor = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "lz",
+		Subsystem: "solver",
+		Name:      "real_time_factor",
+		Help:      "最近一个推送周期内推进的铸造时间与墙钟时间之比，小于 1 表示计算跟不上现场",
+	}, []string{"calculator"})
+	sliceCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "lz",
+		Subsystem: "solver",
+		Name:      "slices",
+		Help:      "铸机中的切片数",
+	}, []string{"calculator"})
+)
+
+// 计算器编号，用于区分同时运行的多个计算器的指标
+var calculatorSeq atomic.Int64
+
+// 单个计算器的运行指标
+type solverMetrics struct {
+	id          string
+	windowStart time.Time     // 当前推送周期开始的时间
+	simulated   time.Duration // 当前推送周期内推进的铸造时间
+}
+
+func newSolverMetrics() solverMetrics {
+	return solverMetrics{id: strconv.FormatInt(calculatorSeq.Add(1), 10)}
+}
+
+// 开始计算时重置推送周期
+func (m *solverMetrics) start() {
+	m.windowStart = time.Now()
+	m.simulated = 0
+}
+
+// 记录一个时间步长，timeStep 为 0 表示没有切片时直接生成切片，只计入推进的铸造时间
+func (m *solverMetrics) observeStep(wall, timeStep time.Duration, deltaT float32, slices int) {
+	if timeStep > 0 {
+		stepDuration.Observe(wall.Seconds())
+		timeStepDuration.Observe(timeStep.Seconds())
+		deltaTSeconds.Observe(float64(deltaT))
+	}
+	simulatedSeconds.Add(float64(deltaT))
+	sliceCount.WithLabelValues(m.id).Set(float64(slices))
+	m.simulated += time.Duration(int64(deltaT * 1e9))
+}
+
+// 推送前更新实时因子并开始新的推送周期，等待推送的时间计入下一个周期
+func (m *solverMetrics) observePush() {
+	if elapsed := time.Since(m.windowStart); elapsed > 0 {
+		realTimeFactor.WithLabelValues(m.id).Set(m.simulated.Seconds() / elapsed.Seconds())
+	}
+	m.start()
+}
+
+// 停止计算后删除该计算器的指标
+func (m *solverMetrics) remove() {
+	realTimeFactor.DeleteLabelValues(m.id)
+	sliceCount.DeleteLabelValues(m.id)
+}
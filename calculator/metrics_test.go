@@ -0,0 +1,31 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSolverMetrics(t *testing.T) {
+	m := newSolverMetrics()
+	m.start()
+	m.windowStart = time.Now().Add(-2 * time.Second)
+	m.observeStep(0, 0, 1, 10)
+	m.observeStep(time.Millisecond, time.Microsecond, 3, 12)
+	m.observePush()
+	factor := testutil.ToFloat64(realTimeFactor.WithLabelValues(m.id))
+	if factor < 1.9 || factor > 2 {
+		t.Fatal("2s 内推进 4s，实时因子应约为 2:", factor)
+	}
+	if slices := testutil.ToFloat64(sliceCount.WithLabelValues(m.id)); slices != 12 {
+		t.Fatal("切片数应为 12:", slices)
+	}
+	if m.simulated != 0 {
+		t.Fatal("推送后应开始新的推送周期:", m.simulated)
+	}
+	m.remove()
+	if n := testutil.CollectAndCount(realTimeFactor); n != 0 {
+		t.Fatal("停止计算后应删除实时因子:", n)
+	}
+}
@@ -0,0 +1,129 @@
+package calculator
+
+import (
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"math"
+)
+
+const (
+	DefaultMoldLevelWindow = 50 // 默认使用最近 50 个测量值计算液面波动
+	StableLevelDeviation   = 3  // 液面波动在 ±3mm 以内认为液面稳定
+	UnstableLevelDeviation = 5  // 液面波动超过 ±5mm 认为液面不稳定
+)
+
+// 弯月面稳定性状态
+const (
+	MeniscusStable      = "stable"
+	MeniscusFluctuating = "fluctuating"
+	MeniscusUnstable    = "unstable"
+)
+
+// 结晶器液面状态
+type moldLevel struct {
+	setpoint float32
+	window   int
+	samples  []float32 // 最近的测量值，按时间顺序
+	pending  float32   // 待生效的有效液面高度，-1 表示没有
+}
+
+// 弯月面稳定性指标
+type MeniscusStability struct {
+	Setpoint      float32 `json:"setpoint"`
+	Level         float32 `json:"level"`          // 最近一次测量值
+	MeanLevel     float32 `json:"mean_level"`     // 窗口内的平均液面，作为有效液面高度
+	Deviation     float32 `json:"deviation"`      // 窗口内液面的标准差
+	MaxDeviation  float32 `json:"max_deviation"`  // 窗口内偏离设定值的最大值
+	ContactLength float32 `json:"contact_length"` // 有效结晶器接触长度 mm
+	Samples       int     `json:"samples"`
+	State         string  `json:"state"`
+}
+
+// 设置液面设定值，设定值改变后清空测量值，没有测量值时按设定值计算
+func (c *CastingMachine) SetMoldLevelCfg(cfg model.MoldLevelCfg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	window := cfg.Window
+	if window <= 0 {
+		window = DefaultMoldLevelWindow
+	}
+	c.moldLevel = moldLevel{
+		setpoint: cfg.Setpoint,
+		window:   window,
+		samples:  make([]float32, 0, window),
+		pending:  cfg.Setpoint,
+	}
+	log.WithField("cfg", cfg).Info("设置结晶器液面")
+}
+
+// 加入一个液面测量值，有效液面高度取窗口内的平均值，在下一个计算周期生效
+func (c *CastingMachine) AddMoldLevel(level float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l := &c.moldLevel
+	if l.window == 0 {
+		// 没有设置设定值时以初始液面高度作为设定值
+		l.setpoint, l.window = c.Coordinate.LevelHeight, DefaultMoldLevelWindow
+	}
+	if len(l.samples) >= l.window {
+		copy(l.samples, l.samples[1:])
+		l.samples = l.samples[:len(l.samples)-1]
+	}
+	l.samples = append(l.samples, level)
+	l.pending = mean(l.samples)
+}
+
+// 应用待生效的液面高度，在计算协程中调用
+func (c *CastingMachine) applyMoldLevel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.moldLevel.window == 0 || c.moldLevel.pending < 0 {
+		return
+	}
+	c.Coordinate.LevelHeight = c.moldLevel.pending
+	c.moldLevel.pending = -1
+}
+
+// 弯月面稳定性
+func (c *CastingMachine) GetMeniscusStability() *MeniscusStability {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l := c.moldLevel
+	res := &MeniscusStability{
+		Setpoint:      l.setpoint,
+		Level:         c.Coordinate.LevelHeight,
+		MeanLevel:     c.Coordinate.LevelHeight,
+		ContactLength: float32(c.Coordinate.MdLength) - c.Coordinate.LevelHeight,
+		Samples:       len(l.samples),
+		State:         MeniscusStable,
+	}
+	if len(l.samples) == 0 {
+		return res
+	}
+	res.Level = l.samples[len(l.samples)-1]
+	res.MeanLevel = mean(l.samples)
+	var variance float64
+	for _, level := range l.samples {
+		variance += math.Pow(float64(level-res.MeanLevel), 2)
+		if d := float32(math.Abs(float64(level - l.setpoint))); d > res.MaxDeviation {
+			res.MaxDeviation = d
+		}
+	}
+	res.Deviation = float32(math.Sqrt(variance / float64(len(l.samples))))
+	res.ContactLength = float32(c.Coordinate.MdLength) - res.MeanLevel
+	switch {
+	case res.MaxDeviation > UnstableLevelDeviation:
+		res.State = MeniscusUnstable
+	case res.MaxDeviation > StableLevelDeviation:
+		res.State = MeniscusFluctuating
+	}
+	return res
+}
+
+func mean(values []float32) float32 {
+	var sum float32
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float32(len(values))
+}
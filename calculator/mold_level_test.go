@@ -0,0 +1,41 @@
+package calculator
+
+import (
+	"lz/model"
+	"testing"
+)
+
+func TestMoldLevel(t *testing.T) {
+	c := NewCastingMachine()
+	c.Coordinate.MdLength = 950
+	c.Coordinate.LevelHeight = 100
+	c.SetMoldLevelCfg(model.MoldLevelCfg{Setpoint: 110, Window: 4})
+	c.applyMoldLevel()
+	if c.Coordinate.LevelHeight != 110 {
+		t.Fatal("没有测量值时应按设定值计算:", c.Coordinate.LevelHeight)
+	}
+	if s := c.GetMeniscusStability(); s.State != MeniscusStable || s.ContactLength != 840 {
+		t.Fatalf("弯月面稳定性不正确: %+v", s)
+	}
+
+	for _, level := range []float32{108, 112, 114, 106} {
+		c.AddMoldLevel(level)
+	}
+	if c.Coordinate.LevelHeight != 110 {
+		t.Fatal("液面高度应在下一个计算周期生效")
+	}
+	s := c.GetMeniscusStability()
+	if s.MeanLevel != 110 || s.MaxDeviation != 4 || s.State != MeniscusFluctuating || s.Level != 106 {
+		t.Fatalf("弯月面稳定性不正确: %+v", s)
+	}
+	// 超出窗口的测量值被丢弃
+	c.AddMoldLevel(118)
+	c.applyMoldLevel()
+	s = c.GetMeniscusStability()
+	if s.Samples != 4 || s.MeanLevel != 112.5 || s.State != MeniscusUnstable {
+		t.Fatalf("弯月面稳定性不正确: %+v", s)
+	}
+	if c.Coordinate.LevelHeight != 112.5 || s.ContactLength != 837.5 {
+		t.Fatal("有效液面高度应为窗口内的平均值:", c.Coordinate.LevelHeight)
+	}
+}
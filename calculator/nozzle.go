@@ -0,0 +1,207 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+)
+
+// 故障喷嘴处表面温度比相邻正常喷嘴高出该值时认为是严重故障
+const CriticalTemperatureRise = 30
+
+var ErrNozzleNotFound = errors.New("喷嘴不存在")
+
+// 设置喷嘴状态，出错时不修改任何喷嘴，在下一次计算换热系数时生效
+// 复制一份喷嘴布置修改后整体替换，避免计算过程中读到一半修改的数据
+func (c *CastingMachine) SetNozzleStates(states []model.NozzleState) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nozzleCfg := c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg
+	if c.pendingNozzle != nil {
+		nozzleCfg = *c.pendingNozzle
+	}
+	wideItems := append([]model.WideItem(nil), nozzleCfg.WideItems...)
+	narrowItems := append([]model.NarrowItem(nil), nozzleCfg.NarrowItems...)
+	for _, state := range states {
+		found := false
+		switch state.Side {
+		case "wide":
+			for i := range wideItems {
+				if wideItems[i].RollerNum == state.RollerNum {
+					wideItems[i].Disabled, wideItems[i].Efficiency = state.Disabled, copyEfficiency(state.Efficiency)
+					found = true
+				}
+			}
+		case "narrow":
+			for i := range narrowItems {
+				if narrowItems[i].RollerNum == state.RollerNum {
+					narrowItems[i].Disabled, narrowItems[i].Efficiency = state.Disabled, copyEfficiency(state.Efficiency)
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: %s %d", ErrNozzleNotFound, state.Side, state.RollerNum)
+		}
+	}
+	c.pendingNozzle = &model.NozzleCfg{
+		WideItems:   wideItems,
+		NarrowItems: narrowItems,
+	}
+	log.WithField("states", states).Info("喷嘴状态已更新")
+	return nil
+}
+
+// 复制喷嘴效率，不与请求共用同一个值
+func copyEfficiency(e *float32) *float32 {
+	if e == nil {
+		return nil
+	}
+	v := *e
+	return &v
+}
+
+// 应用待生效的喷嘴布置，在计算协程中调用
+func (c *CastingMachine) applyPendingNozzle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pendingNozzle != nil {
+		c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg = *c.pendingNozzle
+		c.pendingNozzle = nil
+	}
+}
+
+// 单个故障喷嘴对表面温度的影响
+type NozzleFailure struct {
+	Side                string  `json:"side"`
+	RollerNum           int     `json:"roller_num"`
+	CoolingZone         int     `json:"cooling_zone"`
+	StartDistance       float32 `json:"start_distance"`
+	EndDistance         float32 `json:"end_distance"`
+	FlowFactor          float32 `json:"flow_factor"`
+	SurfaceTemperature  float32 `json:"surface_temperature"`  // 喷嘴影响区域的平均表面温度
+	NeighborTemperature float32 `json:"neighbor_temperature"` // 相邻正常喷嘴影响区域的平均表面温度
+	TemperatureRise     float32 `json:"temperature_rise"`
+	Critical            bool    `json:"critical"`
+}
+
+// 报警信息
+func (f NozzleFailure) message() string {
+	return fmt.Sprintf("喷嘴 %s %d 严重故障，表面温度升高 %.1f℃", f.Side, f.RollerNum, f.TemperatureRise)
+}
+
+// 喷嘴故障报告
+type NozzleReport struct {
+	Failures []NozzleFailure `json:"failures"`
+}
+
+// 喷嘴的影响区域以及状态，用于生成报告
+type nozzleSpan struct {
+	side        string
+	rollerNum   int
+	coolingZone int
+	start, end  float32
+	factor      float32
+	temperature float32
+	hasData     bool
+}
+
+// 生成喷嘴故障报告，按故障喷嘴与相邻正常喷嘴的表面温差判断是否严重
+func (c *calculatorWithArrDeque) GenerateNozzleReport() *NozzleReport {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
+	return c.nozzleReport()
+}
+
+// 生成喷嘴故障报告，调用方持有 fieldMu
+func (c *calculatorWithArrDeque) nozzleReport() *NozzleReport {
+	report := &NozzleReport{Failures: make([]NozzleFailure, 0)}
+	nozzleCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg
+	mdEnd := float32(c.castingMachine.Coordinate.MdLength) - c.castingMachine.Coordinate.LevelHeight
+
+	wide := make([]nozzleSpan, len(nozzleCfg.WideItems))
+	pre := mdEnd
+	for i, item := range nozzleCfg.WideItems {
+		wide[i] = nozzleSpan{side: "wide", rollerNum: item.RollerNum, coolingZone: item.CoolingZone, start: pre, end: item.Distance, factor: item.FlowFactor()}
+		pre = item.Distance
+	}
+	narrow := make([]nozzleSpan, len(nozzleCfg.NarrowItems))
+	pre = mdEnd
+	for i, item := range nozzleCfg.NarrowItems {
+		narrow[i] = nozzleSpan{side: "narrow", rollerNum: item.RollerNum, coolingZone: item.CoolingZone, start: pre, end: pre + item.RollerDistance, factor: item.FlowFactor()}
+		pre += item.RollerDistance
+	}
+	for _, spans := range [][]nozzleSpan{wide, narrow} {
+		for i := range spans {
+			spans[i].temperature, spans[i].hasData = c.surfaceTemperature(spans[i].side, spans[i].start, spans[i].end)
+		}
+		for i, span := range spans {
+			if span.factor >= 1 {
+				continue
+			}
+			failure := NozzleFailure{
+				Side:               span.side,
+				RollerNum:          span.rollerNum,
+				CoolingZone:        span.coolingZone,
+				StartDistance:      span.start,
+				EndDistance:        span.end,
+				FlowFactor:         span.factor,
+				SurfaceTemperature: span.temperature,
+			}
+			if neighbor, ok := neighborTemperature(spans, i); ok && span.hasData {
+				failure.NeighborTemperature = neighbor
+				failure.TemperatureRise = span.temperature - neighbor
+				failure.Critical = failure.TemperatureRise >= CriticalTemperatureRise
+			}
+			report.Failures = append(report.Failures, failure)
+		}
+	}
+	return report
+}
+
+// 前后最近的正常喷嘴的平均表面温度
+func neighborTemperature(spans []nozzleSpan, i int) (float32, bool) {
+	var sum float32
+	var count int
+	for j := i - 1; j >= 0; j-- {
+		if spans[j].factor >= 1 && spans[j].hasData {
+			sum += spans[j].temperature
+			count++
+			break
+		}
+	}
+	for j := i + 1; j < len(spans); j++ {
+		if spans[j].factor >= 1 && spans[j].hasData {
+			sum += spans[j].temperature
+			count++
+			break
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float32(count), true
+}
+
+// [start, end) 范围内宽面或窄面中心的平均表面温度，范围内没有切片时返回 false
+func (c *calculatorWithArrDeque) surfaceTemperature(side string, start, end float32) (float32, bool) {
+	var sum float32
+	var count int
+	for z := int(start) / ZStep; z < int(end)/ZStep && z < c.Field.Size(); z++ {
+		slice := c.Field.GetSlice(z)
+		if slice[0][0] == -1 {
+			continue
+		}
+		if side == "wide" {
+			sum += slice[Width/YStep-1][0]
+		} else {
+			sum += slice[0][Length/XStep-1]
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float32(count), true
+}
@@ -0,0 +1,123 @@
+package calculator
+
+import (
+	"encoding/json"
+	"errors"
+	"lz/model"
+	"testing"
+)
+
+func TestSetNozzleStates(t *testing.T) {
+	c := NewCastingMachine()
+	c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg = model.NozzleCfg{
+		WideItems:   []model.WideItem{{RollerNum: 1}, {RollerNum: 2}},
+		NarrowItems: []model.NarrowItem{{RollerNum: 1}},
+	}
+	old := c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg.WideItems
+
+	half := float32(0.5)
+	err := c.SetNozzleStates([]model.NozzleState{
+		{Side: "wide", RollerNum: 2, Efficiency: &half},
+		{Side: "narrow", RollerNum: 1, Disabled: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg.NarrowItems[0].Disabled {
+		t.Fatal("喷嘴状态应在计算协程中生效")
+	}
+	c.applyPendingNozzle()
+	nozzleCfg := c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg
+	if f := nozzleCfg.WideItems[0].FlowFactor(); f != 1 {
+		t.Fatalf("未设置效率的喷嘴水量系数应为 1, 实际为 %v", f)
+	}
+	if f := nozzleCfg.WideItems[1].FlowFactor(); f != 0.5 {
+		t.Fatalf("部分堵塞喷嘴水量系数应为 0.5, 实际为 %v", f)
+	}
+	if f := nozzleCfg.NarrowItems[0].FlowFactor(); f != 0 {
+		t.Fatalf("关闭的喷嘴水量系数应为 0, 实际为 %v", f)
+	}
+	if old[1].Efficiency != nil {
+		t.Fatal("不应修改原喷嘴布置")
+	}
+	half = 1
+	if f := nozzleCfg.WideItems[1].FlowFactor(); f != 0.5 {
+		t.Fatal("不应与请求共用喷嘴效率")
+	}
+
+	// 效率为 0 表示完全堵塞，不设置效率时恢复正常
+	zero := float32(0)
+	err = c.SetNozzleStates([]model.NozzleState{
+		{Side: "wide", RollerNum: 1, Efficiency: &zero},
+		{Side: "narrow", RollerNum: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.applyPendingNozzle()
+	nozzleCfg = c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg
+	if f := nozzleCfg.WideItems[0].FlowFactor(); f != 0 {
+		t.Fatalf("效率为 0 的喷嘴水量系数应为 0, 实际为 %v", f)
+	}
+	if f := nozzleCfg.WideItems[1].FlowFactor(); f != 0.5 {
+		t.Fatalf("未设置的喷嘴应保持原状态, 实际为 %v", f)
+	}
+	if f := nozzleCfg.NarrowItems[0].FlowFactor(); f != 1 {
+		t.Fatalf("恢复的喷嘴水量系数应为 1, 实际为 %v", f)
+	}
+
+	err = c.SetNozzleStates([]model.NozzleState{
+		{Side: "wide", RollerNum: 1, Disabled: true},
+		{Side: "wide", RollerNum: 3, Disabled: true},
+	})
+	if !errors.Is(err, ErrNozzleNotFound) {
+		t.Fatalf("应返回喷嘴不存在错误, 实际为 %v", err)
+	}
+	c.applyPendingNozzle()
+	if c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg.WideItems[0].Disabled {
+		t.Fatal("出错时不应修改任何喷嘴")
+	}
+}
+
+func TestNeighborTemperature(t *testing.T) {
+	spans := []nozzleSpan{
+		{factor: 1, temperature: 900, hasData: true},
+		{factor: 0, temperature: 980, hasData: true},
+		{factor: 0.5, temperature: 950, hasData: true},
+		{factor: 1, temperature: 920, hasData: true},
+	}
+	if temp, ok := neighborTemperature(spans, 1); !ok || temp != 910 {
+		t.Fatalf("相邻正常喷嘴平均温度应为 910, 实际为 %v", temp)
+	}
+}
+
+func TestSetCoolerConfigDropsPending(t *testing.T) {
+	c := NewCastingMachine()
+	c.CoolerConfig.SecondaryCoolingZoneCfg.NozzleCfg = model.NozzleCfg{WideItems: []model.WideItem{{RollerNum: 1}, {RollerNum: 2}}}
+	c.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{{InnerArcWaterVolume: 80}}
+	if err := c.SetNozzleStates([]model.NozzleState{{Side: "wide", RollerNum: 2, Disabled: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetZoneWaterVolume(1, 60); err != nil {
+		t.Fatal(err)
+	}
+
+	// 换成另一台铸机的冷却参数后，原来排队的修改不再生效
+	nozzleCfg, _ := json.Marshal(model.NozzleCfg{WideItems: []model.WideItem{{RollerNum: 1, Distance: 1000}, {RollerNum: 2, Distance: 2000}, {RollerNum: 3, Distance: 3000}}})
+	err := c.SetCoolerConfig(model.Env{
+		SecondaryCoolingWaterCfg: []model.SecondaryCoolingWaterSection{{InnerArcWaterVolume: 100}},
+		CoolingZoneCfg:           []model.CoolingZone{{Start: 1, End: 3}},
+	}, nozzleCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.applyPendingNozzle()
+	c.applyPendingWater()
+	zone := c.CoolerConfig.SecondaryCoolingZoneCfg
+	if len(zone.NozzleCfg.WideItems) != 3 || zone.NozzleCfg.WideItems[1].Disabled {
+		t.Fatalf("不应应用原来铸机的喷嘴状态: %+v", zone.NozzleCfg.WideItems)
+	}
+	if zone.SecondaryCoolingWaterCfg[0].InnerArcWaterVolume != 100 {
+		t.Fatalf("不应应用原来铸机的水量修改: %+v", zone.SecondaryCoolingWaterCfg)
+	}
+}
@@ -0,0 +1,289 @@
+package calculator
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"math"
+	"sort"
+	"time"
+)
+
+// 违反约束时每单位偏差的惩罚
+const constraintPenalty = 100
+
+// 一次评估的结果
+type OptimizeMetrics struct {
+	ZoneTemperatures     map[string]float32 `json:"zone_temperatures"`     // 各冷却区宽面中心平均表面温度
+	MaxReheatingRate     float32            `json:"max_reheating_rate"`    // 最大回温速率 ℃/m
+	UnbendingTemperature float32            `json:"unbending_temperature"` // 矫直点表面温度
+	CraterEnd            float32            `json:"crater_end"`            // 液芯末端位置，-1 表示液芯末端不在铸机内
+	Cost                 float32            `json:"cost"`
+	Feasible             bool               `json:"feasible"` // 是否满足全部约束
+}
+
+// 优化进度
+type OptimizeProgress struct {
+	Speed       float32   `json:"speed"`
+	SpeedIndex  int       `json:"speed_index"`
+	Speeds      int       `json:"speeds"`
+	Evaluation  int       `json:"evaluation"`
+	Evaluations int       `json:"evaluations"`
+	BestCost    float32   `json:"best_cost"`
+	Volumes     []float32 `json:"volumes"` // 当前最优的各冷却区内弧水量
+}
+
+// 单个拉速的优化结果
+type SpeedOptimizeResult struct {
+	Speed                    float32                              `json:"speed"`
+	SecondaryCoolingWaterCfg []model.SecondaryCoolingWaterSection `json:"secondary_cooling_water_cfg"`
+	Metrics                  OptimizeMetrics                      `json:"metrics"`
+}
+
+// 优化结果，SecondaryCoolingWaterCfg 为第一个拉速的推荐水量
+type OptimizeResult struct {
+	SteelValue               int                                  `json:"steel_value"`
+	SecondaryCoolingWaterCfg []model.SecondaryCoolingWaterSection `json:"secondary_cooling_water_cfg"`
+	WaterTables              []model.WaterTable                   `json:"water_tables"`
+	Results                  []SpeedOptimizeResult                `json:"results"`
+}
+
+// 按各冷却区内弧水量计算温度场并评估
+type evaluateFunc func(volumes []float32) OptimizeMetrics
+
+// 离线优化二冷水量，使用当前铸机配置和断面新建一个计算器，不影响在线计算
+// 每次评估都用温度场模型计算到稳态，耗时较长，通过 progress 报告进度，ctx 取消时返回 ctx.Err()
+func (c *calculatorWithArrDeque) Optimize(ctx context.Context, cfg model.OptimizeCfg, progress func(OptimizeProgress)) (*OptimizeResult, error) {
+	o := NewCalculatorWithArrDeque(nil)
+	defer o.Close()
+	o.castingMachine = c.castingMachine.clone()
+	o.steel1 = NewSteel(cfg.SteelValue, o.castingMachine)
+	o.runningState = stateRunning
+
+	speeds := append([]float32(nil), cfg.Speeds...)
+	sort.Slice(speeds, func(i, j int) bool { return speeds[i] < speeds[j] })
+	coolingZoneCfg := o.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg
+	waterCfg := o.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	volumes := make([]float32, len(waterCfg))
+	for i := range waterCfg {
+		volumes[i] = clamp(waterCfg[i].InnerArcWaterVolume, cfg.MinVolume, cfg.MaxVolume)
+	}
+	zones := optimizedZones(coolingZoneCfg, cfg.TargetTemperature)
+
+	result := &OptimizeResult{SteelValue: cfg.SteelValue}
+	for i, speed := range speeds {
+		o.castingMachine.CoolerConfig.V = int64(speed * 1000 / 60)
+		evaluate := func(volumes []float32) OptimizeMetrics {
+			for zone, volume := range volumes {
+				scaleZoneVolume(&waterCfg[zone], waterCfg[zone].InnerArcWaterVolume, volume)
+			}
+			o.simulateTransit()
+			return o.optimizeMetrics(cfg)
+		}
+		best, metrics, err := search(ctx, cfg, volumes, zones, evaluate, func(p OptimizeProgress) {
+			p.Speed, p.SpeedIndex, p.Speeds = speed, i, len(speeds)
+			progress(p)
+		})
+		if err != nil {
+			return nil, err
+		}
+		// 下一个拉速从当前结果开始搜索
+		volumes = best
+		for zone, volume := range best {
+			scaleZoneVolume(&waterCfg[zone], waterCfg[zone].InnerArcWaterVolume, volume)
+		}
+		result.Results = append(result.Results, SpeedOptimizeResult{
+			Speed:                    speed,
+			SecondaryCoolingWaterCfg: append([]model.SecondaryCoolingWaterSection(nil), waterCfg...),
+			Metrics:                  metrics,
+		})
+		log.WithFields(log.Fields{"speed": speed, "volumes": best, "cost": metrics.Cost}).Info("冷却制度优化完成一个拉速")
+	}
+	if len(result.Results) > 0 {
+		result.SecondaryCoolingWaterCfg = result.Results[0].SecondaryCoolingWaterCfg
+	}
+	result.WaterTables = buildWaterTables(cfg.SteelValue, coolingZoneCfg, result.Results)
+	return result, nil
+}
+
+// 参与优化的冷却区下标，没有设置目标温度时优化全部冷却区
+func optimizedZones(coolingZoneCfg []model.CoolingZone, targets map[string]float32) []int {
+	zones := make([]int, 0, len(coolingZoneCfg))
+	for i, zone := range coolingZoneCfg {
+		if _, ok := targets[zone.ZoneName]; ok || len(targets) == 0 {
+			zones = append(zones, i)
+		}
+	}
+	return zones
+}
+
+// 坐标下降搜索：逐个冷却区尝试增减水量，没有改进时步长减半
+func search(ctx context.Context, cfg model.OptimizeCfg, initial []float32, zones []int, evaluate evaluateFunc, progress func(OptimizeProgress)) ([]float32, OptimizeMetrics, error) {
+	best := append([]float32(nil), initial...)
+	bestMetrics := evaluate(best)
+	evaluations := 1
+	step := (cfg.MaxVolume - cfg.MinVolume) / 4
+	report := func() {
+		progress(OptimizeProgress{
+			Evaluation:  evaluations,
+			Evaluations: cfg.Iterations,
+			BestCost:    bestMetrics.Cost,
+			Volumes:     append([]float32(nil), best...),
+		})
+	}
+	report()
+	for step >= 1 && evaluations < cfg.Iterations {
+		improved := false
+		for _, zone := range zones {
+			for _, dir := range []float32{1, -1} {
+				if evaluations >= cfg.Iterations {
+					break
+				}
+				if err := ctx.Err(); err != nil {
+					return nil, OptimizeMetrics{}, err
+				}
+				candidate := append([]float32(nil), best...)
+				candidate[zone] = clamp(best[zone]+dir*step, cfg.MinVolume, cfg.MaxVolume)
+				if candidate[zone] == best[zone] {
+					continue
+				}
+				metrics := evaluate(candidate)
+				evaluations++
+				if metrics.Cost < bestMetrics.Cost {
+					best, bestMetrics, improved = candidate, metrics, true
+				}
+				report()
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+	return best, bestMetrics, nil
+}
+
+// 计算到铸坯通过整个铸机，保证温度场达到当前水量下的稳态
+func (c *calculatorWithArrDeque) simulateTransit() {
+	v := c.castingMachine.CoolerConfig.V
+	if v <= 0 {
+		return
+	}
+	transit := time.Duration(float64(ZLength) / float64(v) * float64(time.Second))
+	oneSlice := time.Duration(float64(ZStep) / float64(v) * float64(time.Second))
+	for elapsed := time.Duration(0); elapsed < transit || !c.Field.IsFull(); {
+		var deltaT float32
+		if c.Field.Size() == 0 {
+			deltaT = float32(oneSlice.Seconds())
+		} else {
+			c.calculateQAndHeffOnline()
+			deltaT, _ = c.calculateTimeStep()
+			c.e.dispatchTask(deltaT, 0, c.Field.Size())
+		}
+		if c.alternating {
+			c.Field = c.thermalField1
+		} else {
+			c.Field = c.thermalField
+		}
+		c.maskNarrowedSlices()
+		dt := time.Duration(int64(deltaT * 1e9))
+		c.updateSliceInfo(dt)
+		c.alternating = !c.alternating
+		elapsed += dt
+	}
+}
+
+// 根据当前温度场计算优化目标和约束
+func (c *calculatorWithArrDeque) optimizeMetrics(cfg model.OptimizeCfg) OptimizeMetrics {
+	coolingZoneCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg
+	temps := c.zoneSurfaceTemperatures(len(coolingZoneCfg))
+	metrics := OptimizeMetrics{ZoneTemperatures: make(map[string]float32, len(coolingZoneCfg))}
+	for i, zone := range coolingZoneCfg {
+		metrics.ZoneTemperatures[zone.ZoneName] = temps[i]
+	}
+
+	// 每隔 1m 比较一次宽面中心表面温度
+	window := 1000 / ZStep
+	if window > c.Field.Size()-1 {
+		window = c.Field.Size() - 1
+	}
+	mdEnd := int(float32(c.castingMachine.Coordinate.MdLength)-c.castingMachine.Coordinate.LevelHeight) / ZStep
+	for z := mdEnd; window > 0 && z+window < c.Field.Size(); z++ {
+		pre, cur := c.Field.GetSlice(z), c.Field.GetSlice(z+window)
+		if pre[0][0] == -1 || cur[0][0] == -1 {
+			continue
+		}
+		rate := (cur[Width/YStep-1][0] - pre[Width/YStep-1][0]) * 1000 / float32(window*ZStep)
+		if rate > metrics.MaxReheatingRate {
+			metrics.MaxReheatingRate = rate
+		}
+	}
+	unbending := int(c.castingMachine.Coordinate.CenterEndDistance) / ZStep
+	if unbending > 0 && unbending < c.Field.Size() {
+		metrics.UnbendingTemperature = c.Field.GetSlice(unbending)[Width/YStep-1][0]
+	}
+	metrics.CraterEnd = c.craterEnd()
+	metrics.Cost, metrics.Feasible = optimizeCost(cfg, metrics)
+	return metrics
+}
+
+// 目标函数：与目标温度偏差的均方值加上违反约束的惩罚
+func optimizeCost(cfg model.OptimizeCfg, metrics OptimizeMetrics) (float32, bool) {
+	var cost, penalty float32
+	var count int
+	for name, target := range cfg.TargetTemperature {
+		temp, ok := metrics.ZoneTemperatures[name]
+		if !ok || temp == 0 {
+			continue
+		}
+		cost += (temp - target) * (temp - target)
+		count++
+	}
+	if count > 0 {
+		cost /= float32(count)
+	}
+	if cfg.MaxReheatingRate > 0 && metrics.MaxReheatingRate > cfg.MaxReheatingRate {
+		penalty += metrics.MaxReheatingRate - cfg.MaxReheatingRate
+	}
+	if cfg.MinUnbendingTemperature > 0 && metrics.UnbendingTemperature < cfg.MinUnbendingTemperature {
+		penalty += cfg.MinUnbendingTemperature - metrics.UnbendingTemperature
+	}
+	if cfg.MaxCraterEnd > 0 {
+		if metrics.CraterEnd < 0 {
+			// 液芯末端不在铸机内，按超出铸机长度处理
+			penalty += (float32(ZLength) - cfg.MaxCraterEnd) / 10
+		} else if metrics.CraterEnd > cfg.MaxCraterEnd {
+			penalty += (metrics.CraterEnd - cfg.MaxCraterEnd) / 10 // mm -> cm
+		}
+	}
+	return cost + constraintPenalty*penalty, penalty == 0
+}
+
+// 由各拉速的优化结果生成分段线性水表
+func buildWaterTables(steelValue int, coolingZoneCfg []model.CoolingZone, results []SpeedOptimizeResult) []model.WaterTable {
+	tables := make([]model.WaterTable, 0, len(coolingZoneCfg))
+	if len(results) == 0 {
+		return tables
+	}
+	for i, zone := range coolingZoneCfg {
+		table := model.WaterTable{
+			SteelValue: steelValue,
+			ZoneName:   zone.ZoneName,
+			Speed: model.Speed2Water{
+				Bottom: results[0].Speed,
+				Top:    results[len(results)-1].Speed,
+			},
+			Points: make([]model.WaterPoint, 0, len(results)),
+		}
+		for _, r := range results {
+			if i < len(r.SecondaryCoolingWaterCfg) {
+				table.Points = append(table.Points, model.WaterPoint{Speed: r.Speed, Volume: r.SecondaryCoolingWaterCfg[i].InnerArcWaterVolume})
+			}
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+func clamp(v, min, max float32) float32 {
+	return float32(math.Max(float64(min), math.Min(float64(max), float64(v))))
+}
@@ -0,0 +1,71 @@
+package calculator
+
+import (
+	"context"
+	"lz/model"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	cfg := model.OptimizeCfg{
+		TargetTemperature: map[string]float32{"1": 900, "2": 800},
+		MinVolume:         0,
+		MaxVolume:         400,
+		Iterations:        200,
+	}
+	// 表面温度随水量线性下降，最优水量为 [100, 300]
+	evaluate := func(volumes []float32) OptimizeMetrics {
+		metrics := OptimizeMetrics{ZoneTemperatures: map[string]float32{
+			"1": 1000 - volumes[0],
+			"2": 1100 - volumes[1],
+		}}
+		metrics.Cost, metrics.Feasible = optimizeCost(cfg, metrics)
+		return metrics
+	}
+	var reports int
+	best, metrics, err := search(context.Background(), cfg, []float32{200, 200}, []int{0, 1}, evaluate, func(OptimizeProgress) {
+		reports++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best[0] < 99 || best[0] > 101 || best[1] < 299 || best[1] > 301 {
+		t.Fatalf("最优水量应接近 [100, 300], 实际为 %v", best)
+	}
+	if !metrics.Feasible || reports == 0 {
+		t.Fatal("应满足约束并报告进度")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err = search(ctx, cfg, []float32{200, 200}, []int{0, 1}, evaluate, func(OptimizeProgress) {}); err != context.Canceled {
+		t.Fatal("取消后应返回 context.Canceled:", err)
+	}
+}
+
+func TestOptimizeCost(t *testing.T) {
+	cfg := model.OptimizeCfg{MaxReheatingRate: 100, MinUnbendingTemperature: 900, MaxCraterEnd: 20000}
+	cost, feasible := optimizeCost(cfg, OptimizeMetrics{MaxReheatingRate: 50, UnbendingTemperature: 950, CraterEnd: 15000})
+	if cost != 0 || !feasible {
+		t.Fatal("满足全部约束时代价应为 0:", cost)
+	}
+	cost, feasible = optimizeCost(cfg, OptimizeMetrics{MaxReheatingRate: 110, UnbendingTemperature: 880, CraterEnd: 20100})
+	if feasible || cost != constraintPenalty*(10+20+10) {
+		t.Fatal("违反约束时的惩罚不正确:", cost)
+	}
+}
+
+func TestBuildWaterTables(t *testing.T) {
+	zones := []model.CoolingZone{{ZoneName: "1"}}
+	results := []SpeedOptimizeResult{
+		{Speed: 1.0, SecondaryCoolingWaterCfg: []model.SecondaryCoolingWaterSection{{InnerArcWaterVolume: 100}}},
+		{Speed: 1.5, SecondaryCoolingWaterCfg: []model.SecondaryCoolingWaterSection{{InnerArcWaterVolume: 160}}},
+	}
+	tables := buildWaterTables(3, zones, results)
+	if len(tables) != 1 || len(tables[0].Points) != 2 || tables[0].SteelValue != 3 {
+		t.Fatalf("水表不正确: %+v", tables)
+	}
+	if v := lookupWaterTable(tables[0], 1.25); v != 130 {
+		t.Fatalf("拉速 1.25 对应水量应为 130, 实际为 %v", v)
+	}
+}
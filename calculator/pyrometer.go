@@ -0,0 +1,177 @@
+package calculator
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"math"
+	"time"
+)
+
+const DefaultPyrometerWindow = 100 // 默认使用最近 100 次比较统计残差
+
+// 测温仪状态
+type pyrometer struct {
+	model.Pyrometer
+	measured  float32
+	updated   time.Time // 最近一次测量的时间，从未测量时为零值
+	compared  bool      // 最近一次测量值是否已经比较过，每个测量值只比较一次
+	computed  float32   // 最近一次比较时的计算表面温度
+	residuals []float32 // 最近的残差，按时间顺序
+}
+
+// 全部测温仪
+type pyrometers struct {
+	window int
+	points []*pyrometer
+}
+
+// 单个测温仪的测量值与计算表面温度的比较
+type PyrometerComparison struct {
+	model.Pyrometer
+	Measured     float32   `json:"measured"`      // 最近一次测量值
+	Computed     float32   `json:"computed"`      // 最近一次比较时同一位置的计算表面温度
+	Residual     float32   `json:"residual"`      // 最近一次比较的残差，测量值 - 计算值
+	Updated      time.Time `json:"updated"`       // 最近一次测量的时间，从未测量时为零值
+	Samples      int       `json:"samples"`       // 窗口内的比较次数
+	MeanResidual float32   `json:"mean_residual"` // 窗口内的平均残差，反映模型的系统偏差
+	StdResidual  float32   `json:"std_residual"`  // 窗口内残差的标准差
+	MaxResidual  float32   `json:"max_residual"`  // 窗口内绝对值最大的残差
+	RMSE         float32   `json:"rmse"`          // 窗口内残差的均方根
+}
+
+// 测温仪比较结果
+type PyrometerReport struct {
+	Points       []PyrometerComparison `json:"points"`
+	Samples      int                   `json:"samples"`       // 全部测温仪窗口内的比较次数
+	MeanResidual float32               `json:"mean_residual"` // 全部测温仪的平均残差
+	RMSE         float32               `json:"rmse"`          // 全部测温仪残差的均方根
+}
+
+// 设置测温仪测量点，清空已有的测量值和统计
+func (c *CastingMachine) SetPyrometers(cfg model.PyrometerCfg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	window := cfg.Window
+	if window <= 0 {
+		window = DefaultPyrometerWindow
+	}
+	c.pyrometers = pyrometers{
+		window: window,
+		points: make([]*pyrometer, 0, len(cfg.Points)),
+	}
+	for _, p := range cfg.Points {
+		c.pyrometers.points = append(c.pyrometers.points, &pyrometer{
+			Pyrometer: p,
+			residuals: make([]float32, 0, window),
+		})
+	}
+	log.WithField("cfg", cfg).Info("设置测温仪")
+}
+
+// 加入一个测温仪测量值，在下一次推送前与计算表面温度比较
+func (c *CastingMachine) AddPyrometerReading(id string, temperature float32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p := c.pyrometers.find(id)
+	if p == nil {
+		return fmt.Errorf("测温仪 %s 不存在", id)
+	}
+	p.measured, p.updated, p.compared = temperature, time.Now(), false
+	return nil
+}
+
+// 用没有比较过的测量值与计算表面温度比较，surface 返回测量点处的计算表面温度，
+// 测量点处还没有铸坯时返回 false。在计算协程中调用
+func (c *CastingMachine) comparePyrometers(surface func(model.Pyrometer) (float32, bool)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range c.pyrometers.points {
+		if p.updated.IsZero() || p.compared {
+			continue
+		}
+		computed, ok := surface(p.Pyrometer)
+		if !ok {
+			continue
+		}
+		if len(p.residuals) >= c.pyrometers.window {
+			copy(p.residuals, p.residuals[1:])
+			p.residuals = p.residuals[:len(p.residuals)-1]
+		}
+		p.residuals = append(p.residuals, p.measured-computed)
+		p.computed, p.compared = computed, true
+	}
+}
+
+// 测温仪比较结果，没有配置测温仪时 Points 为空
+func (c *CastingMachine) GetPyrometerReport() *PyrometerReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res := &PyrometerReport{Points: make([]PyrometerComparison, 0, len(c.pyrometers.points))}
+	var sum, squares float64
+	for _, p := range c.pyrometers.points {
+		comparison := PyrometerComparison{
+			Pyrometer: p.Pyrometer,
+			Measured:  p.measured,
+			Computed:  p.computed,
+			Updated:   p.updated,
+			Samples:   len(p.residuals),
+		}
+		if len(p.residuals) > 0 {
+			comparison.Residual = p.residuals[len(p.residuals)-1]
+			comparison.MeanResidual = mean(p.residuals)
+			var variance, square float64
+			for _, r := range p.residuals {
+				variance += math.Pow(float64(r-comparison.MeanResidual), 2)
+				square += float64(r * r)
+				if math.Abs(float64(r)) > math.Abs(float64(comparison.MaxResidual)) {
+					comparison.MaxResidual = r
+				}
+			}
+			comparison.StdResidual = float32(math.Sqrt(variance / float64(len(p.residuals))))
+			comparison.RMSE = float32(math.Sqrt(square / float64(len(p.residuals))))
+			sum += float64(comparison.MeanResidual) * float64(len(p.residuals))
+			squares += square
+			res.Samples += len(p.residuals)
+		}
+		res.Points = append(res.Points, comparison)
+	}
+	if res.Samples > 0 {
+		res.MeanResidual = float32(sum / float64(res.Samples))
+		res.RMSE = float32(math.Sqrt(squares / float64(res.Samples)))
+	}
+	return res
+}
+
+func (p *pyrometers) find(id string) *pyrometer {
+	for _, point := range p.points {
+		if point.ID == id {
+			return point
+		}
+	}
+	return nil
+}
+
+// 与测温仪比较，在计算协程中推送前调用
+func (c *calculatorWithArrDeque) comparePyrometers() {
+	c.castingMachine.comparePyrometers(c.surfaceTemperatureAt)
+}
+
+// 测量点处的计算表面温度，铸坯对称，内弧和外弧宽面取同一表面，偏离中心线的方向不影响结果
+func (c *calculatorWithArrDeque) surfaceTemperatureAt(p model.Pyrometer) (float32, bool) {
+	z := int(p.Distance / float32(ZStep))
+	if z < 0 || z >= c.Field.Size() {
+		return 0, false
+	}
+	slice := c.Field.GetSlice(z)
+	if slice[0][0] == -1 {
+		return 0, false
+	}
+	offset := float32(math.Abs(float64(p.Offset)))
+	if p.Face == model.FaceNarrow {
+		j := int(min(offset/float32(YStep), float32(Width/YStep-1)))
+		return slice[j][Length/XStep-1], true
+	}
+	i := int(min(offset/float32(XStep), float32(Length/XStep-1)))
+	return slice[Width/YStep-1][i], true
+}
@@ -0,0 +1,55 @@
+package calculator
+
+import (
+	"lz/model"
+	"testing"
+)
+
+func TestPyrometers(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	c.castingMachine.SetPyrometers(model.PyrometerCfg{
+		Points: []model.Pyrometer{
+			{ID: "wide", Distance: 25, Face: model.FaceOuter, Offset: -20},
+			{ID: "narrow", Distance: 25, Face: model.FaceNarrow, Offset: 100},
+			{ID: "far", Distance: 150, Face: model.FaceInner},
+		},
+		Window: 2,
+	})
+	for z := 0; z < 5; z++ {
+		c.thermalField.AddFirst(1500)
+	}
+	slice := c.Field.GetSlice(2)
+	slice[Width/YStep-1][20/XStep] = 1000
+	slice[Width/YStep-1][Length/XStep-1] = 900
+	if err := c.castingMachine.AddPyrometerReading("missing", 1000); err == nil {
+		t.Fatal("不存在的测温仪应返回错误")
+	}
+
+	// 每个测量值只比较一次，测量点处没有铸坯时不比较
+	for _, temperature := range []float32{1010, 980, 1030} {
+		for _, id := range []string{"wide", "narrow", "far"} {
+			if err := c.castingMachine.AddPyrometerReading(id, temperature); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c.comparePyrometers()
+		c.comparePyrometers()
+	}
+	report := c.castingMachine.GetPyrometerReport()
+	wide, narrow, far := report.Points[0], report.Points[1], report.Points[2]
+	if wide.Samples != 2 || wide.Computed != 1000 || wide.Residual != 30 || wide.MeanResidual != 5 || wide.MaxResidual != 30 {
+		t.Fatalf("宽面测温仪比较结果不正确: %+v", wide)
+	}
+	if narrow.Computed != 900 || narrow.Residual != 130 {
+		t.Fatalf("窄面测量点超出铸坯时应取角部温度: %+v", narrow)
+	}
+	if far.Samples != 0 || far.Measured != 1030 {
+		t.Fatalf("测量点处没有铸坯时不应比较: %+v", far)
+	}
+	if report.Samples != 4 || report.MeanResidual != 55 {
+		t.Fatalf("总体统计不正确: %+v", report)
+	}
+}
@@ -0,0 +1,196 @@
+package calculator
+
+import (
+	"fmt"
+	"lz/model"
+)
+
+// 扇形段，距离均为距弯月面的距离，单位mm
+type Segment struct {
+	Name           string
+	StartDistance  float32
+	EndDistance    float32
+	EntryThickness float32
+	ExitThickness  float32
+	RollPairs      []model.RollPair
+}
+
+// 根据铸机的扇形段和辊子布置设置扇形段
+func (c *CastingMachine) SetSegments(segments []model.Segment, rollers []model.Roller) error {
+	res := make([]Segment, 0, len(segments))
+	for i, seg := range segments {
+		if seg.Start < 1 || seg.End < seg.Start || seg.End > len(rollers) {
+			return fmt.Errorf("扇形段 %s 辊子范围 [%d, %d] 越界", seg.Name, seg.Start, seg.End)
+		}
+		entry, exit := seg.EntryThickness, seg.ExitThickness
+		if entry == 0 && exit == 0 { // 未配置辊缝时使用铸坯厚度
+			entry = float32(c.Coordinate.Width)
+			exit = entry
+		}
+		s := Segment{
+			Name:           seg.Name,
+			StartDistance:  rollers[seg.Start-1].Distance,
+			EndDistance:    rollers[seg.End-1].Distance,
+			EntryThickness: entry,
+			ExitThickness:  exit,
+			RollPairs:      make([]model.RollPair, 0, seg.End-seg.Start+1),
+		}
+		if i > 0 { // 扇形段从上一个扇形段的最后一个辊子开始
+			s.StartDistance = rollers[segments[i-1].End-1].Distance
+		}
+		for _, roller := range rollers[seg.Start-1 : seg.End] {
+			s.RollPairs = append(s.RollPairs, model.RollPair{
+				RollerNum: roller.RollerNum,
+				Distance:  roller.Distance,
+				Gap:       s.gapAt(roller.Distance),
+			})
+		}
+		res = append(res, s)
+	}
+	c.Segments = res
+	return nil
+}
+
+// 获取距离所在的扇形段下标，不在任何扇形段时返回 -1
+func (c *CastingMachine) WhichSegment(distance float32) int {
+	for i, seg := range c.Segments {
+		if distance > seg.StartDistance && distance <= seg.EndDistance {
+			return i
+		}
+	}
+	if len(c.Segments) > 0 && distance == c.Segments[0].StartDistance {
+		return 0
+	}
+	return -1
+}
+
+// 按入口和出口辊缝线性插值得到名义辊缝
+func (s *Segment) gapAt(distance float32) float32 {
+	if s.EndDistance <= s.StartDistance {
+		return s.EntryThickness
+	}
+	return s.EntryThickness + (s.ExitThickness-s.EntryThickness)*(distance-s.StartDistance)/(s.EndDistance-s.StartDistance)
+}
+
+// 坯壳厚度，单位mm
+type ShellThickness struct {
+	WideSolid    float32 `json:"wide_solid"`
+	WideLiquid   float32 `json:"wide_liquid"`
+	NarrowSolid  float32 `json:"narrow_solid"`
+	NarrowLiquid float32 `json:"narrow_liquid"`
+}
+
+// 扇形段推送数据
+type SegmentData struct {
+	Name              string           `json:"name"`
+	StartDistance     float32          `json:"start_distance"`
+	EndDistance       float32          `json:"end_distance"`
+	EntryThickness    float32          `json:"entry_thickness"`
+	ExitThickness     float32          `json:"exit_thickness"`
+	RollPairs         []model.RollPair `json:"roll_pairs"`
+	Reached           bool             `json:"reached"` // 铸坯是否已到达该扇形段出口
+	EntryShell        ShellThickness   `json:"entry_shell"`
+	ExitShell         ShellThickness   `json:"exit_shell"`
+	ContainsCraterEnd bool             `json:"contains_crater_end"`
+}
+
+type SegmentsData struct {
+	Segments         []SegmentData `json:"segments"`
+	CraterEnd        float32       `json:"crater_end"`         // 液芯末端距弯月面的距离，-1 表示铸机内未完全凝固
+	CraterEndSegment string        `json:"crater_end_segment"` // 液芯末端所在扇形段
+}
+
+// 扇形段坯壳厚度和液芯末端位置
+func (c *calculatorWithArrDeque) GenerateSegmentsData() *SegmentsData {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
+	res := &SegmentsData{
+		Segments:  make([]SegmentData, 0, len(c.castingMachine.Segments)),
+		CraterEnd: c.craterEnd(),
+	}
+	craterSegment := -1
+	if res.CraterEnd >= 0 {
+		craterSegment = c.castingMachine.WhichSegment(res.CraterEnd)
+	}
+	for i, seg := range c.castingMachine.Segments {
+		data := SegmentData{
+			Name:              seg.Name,
+			StartDistance:     seg.StartDistance,
+			EndDistance:       seg.EndDistance,
+			EntryThickness:    seg.EntryThickness,
+			ExitThickness:     seg.ExitThickness,
+			RollPairs:         seg.RollPairs,
+			ContainsCraterEnd: i == craterSegment,
+		}
+		data.EntryShell, _ = c.shellThicknessAt(seg.StartDistance)
+		data.ExitShell, data.Reached = c.shellThicknessAt(seg.EndDistance)
+		if data.ContainsCraterEnd {
+			res.CraterEndSegment = seg.Name
+		}
+		res.Segments = append(res.Segments, data)
+	}
+	return res
+}
+
+// 获取某个位置的坯壳厚度，该位置还没有切片时返回false
+func (c *calculatorWithArrDeque) shellThicknessAt(distance float32) (ShellThickness, bool) {
+	z := int(distance / float32(ZStep))
+	if z < 0 || z >= c.Field.Size() {
+		return ShellThickness{}, false
+	}
+	slice := c.Field.GetSlice(z)
+	if slice[0][0] == -1 {
+		return ShellThickness{}, false
+	}
+	var res ShellThickness
+	res.WideSolid, res.NarrowSolid = shellThickness(slice, c.steel1.SolidPhaseTemperature)
+	res.WideLiquid, res.NarrowLiquid = shellThickness(slice, c.steel1.LiquidPhaseTemperature)
+	return res, true
+}
+
+// 液芯末端位置，即中心温度第一次低于固相线温度的位置
+func (c *calculatorWithArrDeque) craterEnd() float32 {
+	solidTemp := c.steel1.SolidPhaseTemperature
+	for z := 0; z < c.Field.Size(); z++ {
+		slice := c.Field.GetSlice(z)
+		if slice[0][0] != -1 && slice[0][0] <= solidTemp {
+			return float32(z * ZStep)
+		}
+	}
+	return -1
+}
+
+// 计算切片宽面和窄面中心处温度低于 temp 的厚度
+func shellThickness(slice *model.ItemType, temp float32) (wide, narrow float32) {
+	length := Length/XStep - 1
+	width := Width/YStep - 1
+	// 宽面
+	j := width
+	for j = width; j >= 0; j-- {
+		if slice[j][0] > temp {
+			break
+		}
+	}
+	if j == width {
+		wide = 0
+	} else if j < 0 {
+		wide = float32(Width)
+	} else {
+		wide = float32(YStep*(width-j)) + float32(YStep)*(temp-slice[j+1][0])/(slice[j][0]-slice[j+1][0])
+	}
+	// 窄面
+	i := length
+	for i = length; i >= 0; i-- {
+		if slice[0][i] > temp {
+			break
+		}
+	}
+	if i == length {
+		narrow = 0
+	} else if i < 0 || wide == float32(Width) {
+		narrow = float32(Length)
+	} else {
+		narrow = float32(XStep*(length-i)) + float32(XStep)*(temp-slice[0][i+1])/(slice[0][i]-slice[0][i+1])
+	}
+	return wide, narrow
+}
@@ -0,0 +1,37 @@
+package calculator
+
+import (
+	"lz/model"
+	"testing"
+)
+
+func TestSetSegments(t *testing.T) {
+	c := NewCastingMachine()
+	c.SetFromJson(model.Coordinate{Width: 230})
+	rollers := []model.Roller{
+		{RollerNum: 1, Distance: 930},
+		{RollerNum: 2, Distance: 1083},
+		{RollerNum: 3, Distance: 1236},
+		{RollerNum: 4, Distance: 1400},
+	}
+	err := c.SetSegments([]model.Segment{
+		{Name: "足辊", Start: 1, End: 1},
+		{Name: "Seg_0", Start: 2, End: 4, EntryThickness: 230, ExitThickness: 228},
+	}, rollers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := c.Segments[1]
+	if seg.StartDistance != 930 || seg.EndDistance != 1400 {
+		t.Fatal("扇形段范围不正确:", seg.StartDistance, seg.EndDistance)
+	}
+	if seg.RollPairs[len(seg.RollPairs)-1].Gap != 228 || c.Segments[0].RollPairs[0].Gap != 230 {
+		t.Fatal("辊缝不正确:", seg.RollPairs, c.Segments[0].RollPairs)
+	}
+	if c.WhichSegment(930) != 0 || c.WhichSegment(1000) != 1 || c.WhichSegment(2000) != -1 {
+		t.Fatal("扇形段查找不正确")
+	}
+	if err = c.SetSegments([]model.Segment{{Name: "Seg_0", Start: 1, End: 5}}, rollers); err == nil {
+		t.Fatal("辊子下标越界应该报错")
+	}
+}
@@ -0,0 +1,55 @@
+package calculator
+
+// 切片附带的信息，与温度场中的切片一一对应
+type sliceMeta struct {
+	Width              float32 // 切片宽度（宽面长度）mm，0 表示空切片
+	PouringTemperature float32 // 切片进入结晶器时的浇铸温度 ℃
+	HeatID             string  // 切片所属炉次，不属于任何炉次时为空
+}
+
+// 切片信息队列，与温度场同步增删。使用环形数组，first 为下标 0 的切片所在的位置
+type sliceMetaQueue struct {
+	items []sliceMeta
+	first int
+	count int
+}
+
+func newSliceMetaQueue(capacity int) *sliceMetaQueue {
+	return &sliceMetaQueue{
+		items: make([]sliceMeta, capacity),
+	}
+}
+
+func (q *sliceMetaQueue) size() int {
+	return q.count
+}
+
+// 在队列头部增加一个切片的信息，队列已满时丢弃结尾切片的信息
+func (q *sliceMetaQueue) addFirst(meta sliceMeta) {
+	if len(q.items) == 0 {
+		return
+	}
+	if q.count == len(q.items) {
+		q.removeLast()
+	}
+	q.first = (q.first - 1 + len(q.items)) % len(q.items)
+	q.items[q.first] = meta
+	q.count++
+}
+
+// 删除队列结尾切片的信息
+func (q *sliceMetaQueue) removeLast() {
+	if q.count == 0 {
+		return
+	}
+	q.count--
+	q.items[(q.first+q.count)%len(q.items)] = sliceMeta{}
+}
+
+// 获取下标 z 处切片的信息
+func (q *sliceMetaQueue) get(z int) sliceMeta {
+	if z < 0 || z >= q.count {
+		return sliceMeta{}
+	}
+	return q.items[(q.first+z)%len(q.items)]
+}
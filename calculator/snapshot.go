@@ -0,0 +1,42 @@
+package calculator
+
+import "time"
+
+// 降采样后的温度场，用于历史存储
+type FieldSnapshot struct {
+	Time   time.Time     `json:"time"`
+	ZStep  int           `json:"z_step"` // 相邻切片的距离 mm
+	XStep  int           `json:"x_step"` // 宽面方向相邻点的距离 mm
+	YStep  int           `json:"y_step"` // 窄面方向相邻点的距离 mm
+	Slices [][][]float32 `json:"slices"` // 第 i 个切片距弯月面 i*ZStep，为四分之一横截面温度，未浇铸的切片为 nil
+}
+
+// 每隔 zStride 个切片取一个切片，切片内每隔 xyStride 个点取一个点
+func (c *calculatorWithArrDeque) GenerateFieldSnapshot(zStride, xyStride int) *FieldSnapshot {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
+	res := &FieldSnapshot{
+		Time:   time.Now(),
+		ZStep:  zStride * ZStep,
+		XStep:  xyStride * XStep,
+		YStep:  xyStride * YStep,
+		Slices: make([][][]float32, 0, c.Field.Size()/zStride+1),
+	}
+	for z := 0; z < c.Field.Size(); z += zStride {
+		slice := c.Field.GetSlice(z)
+		if slice[0][0] == -1 {
+			res.Slices = append(res.Slices, nil)
+			continue
+		}
+		rows := make([][]float32, 0, Width/YStep/xyStride+1)
+		for j := 0; j < Width/YStep; j += xyStride {
+			row := make([]float32, 0, Length/XStep/xyStride+1)
+			for i := 0; i < Length/XStep; i += xyStride {
+				row = append(row, slice[j][i])
+			}
+			rows = append(rows, row)
+		}
+		res.Slices = append(res.Slices, rows)
+	}
+	return res
+}
@@ -0,0 +1,22 @@
+package calculator
+
+import "testing"
+
+func TestGenerateFieldSnapshot(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	// 浇铸了 5 个切片，取第 0、2、4 个切片
+	for z := 0; z < 5; z++ {
+		c.thermalField.AddFirst(1500)
+	}
+	snapshot := c.GenerateFieldSnapshot(2, 3)
+	if snapshot.ZStep != 2*ZStep || snapshot.XStep != 3*XStep || len(snapshot.Slices) != 3 {
+		t.Fatalf("降采样步长不正确: %d %d %d", snapshot.ZStep, snapshot.XStep, len(snapshot.Slices))
+	}
+	slice := snapshot.Slices[2]
+	if len(slice) != 2 || len(slice[0]) != 4 || slice[1][3] != 1500 {
+		t.Fatalf("降采样后的切片不正确: %v", slice)
+	}
+}
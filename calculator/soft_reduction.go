@@ -0,0 +1,120 @@
+package calculator
+
+import (
+	"lz/model"
+)
+
+// 默认动态轻压下配置
+var defaultSoftReductionCfg = model.SoftReductionCfg{
+	StartSolidFraction:  0.3,
+	EndSolidFraction:    0.7,
+	ReductionRate:       1.0,
+	MaxSegmentReduction: 3.0,
+}
+
+// 单个扇形段的压下建议
+type SegmentReduction struct {
+	Name          string  `json:"name"`
+	StartDistance float32 `json:"start_distance"` // 压下区间与扇形段重合部分的起点
+	EndDistance   float32 `json:"end_distance"`   // 压下区间与扇形段重合部分的终点
+	Reduction     float32 `json:"reduction"`      // 建议压下量 mm
+	EntryGap      float32 `json:"entry_gap"`      // 建议入口辊缝
+	ExitGap       float32 `json:"exit_gap"`       // 建议出口辊缝
+}
+
+// 动态轻压下方案
+type SoftReductionPlan struct {
+	Cfg            model.SoftReductionCfg `json:"cfg"`
+	Found          bool                   `json:"found"`          // 中心固相率是否已进入压下区间
+	Complete       bool                   `json:"complete"`       // 压下区间终点是否在铸机内
+	StartDistance  float32                `json:"start_distance"` // 压下区间起点距弯月面的距离
+	EndDistance    float32                `json:"end_distance"`   // 压下区间终点距弯月面的距离
+	TotalReduction float32                `json:"total_reduction"`
+	Segments       []SegmentReduction     `json:"segments"`
+}
+
+// 设置动态轻压下配置
+func (c *calculatorWithArrDeque) SetSoftReductionCfg(cfg model.SoftReductionCfg) {
+	c.mu.Lock()
+	c.softReductionCfg = cfg
+	c.mu.Unlock()
+}
+
+// 根据铸坯中心固相率生成轻压下方案
+func (c *calculatorWithArrDeque) GenerateSoftReductionPlan() *SoftReductionPlan {
+	c.fieldMu.RLock()
+	defer c.fieldMu.RUnlock()
+	c.mu.Lock()
+	cfg := c.softReductionCfg
+	c.mu.Unlock()
+	plan := &SoftReductionPlan{
+		Cfg:      cfg,
+		Segments: make([]SegmentReduction, 0),
+	}
+	start, end := -1, -1
+	for z := 0; z < c.Field.Size(); z++ {
+		slice := c.Field.GetSlice(z)
+		if slice[0][0] == -1 {
+			continue
+		}
+		fs := c.centerSolidFraction(slice)
+		if start == -1 && fs >= cfg.StartSolidFraction {
+			start = z
+		}
+		if fs >= cfg.EndSolidFraction {
+			end = z
+			break
+		}
+	}
+	if start == -1 {
+		return plan
+	}
+	plan.Found = true
+	plan.Complete = end != -1
+	if end == -1 {
+		end = c.Field.Size() - 1
+	}
+	plan.StartDistance = float32(start * ZStep)
+	plan.EndDistance = float32(end * ZStep)
+
+	var reduced float32 // 之前扇形段的累计压下量
+	for _, seg := range c.castingMachine.Segments {
+		s, e := seg.StartDistance, seg.EndDistance
+		if plan.StartDistance > s {
+			s = plan.StartDistance
+		}
+		if plan.EndDistance < e {
+			e = plan.EndDistance
+		}
+		if e <= s {
+			continue
+		}
+		reduction := cfg.ReductionRate * (e - s) / 1000
+		if cfg.MaxSegmentReduction > 0 && reduction > cfg.MaxSegmentReduction {
+			reduction = cfg.MaxSegmentReduction
+		}
+		plan.Segments = append(plan.Segments, SegmentReduction{
+			Name:          seg.Name,
+			StartDistance: s,
+			EndDistance:   e,
+			Reduction:     reduction,
+			EntryGap:      seg.EntryThickness - reduced,
+			ExitGap:       seg.EntryThickness - reduced - reduction,
+		})
+		reduced += reduction
+	}
+	plan.TotalReduction = reduced
+	return plan
+}
+
+// 切片中心点的固相率
+func (c *calculatorWithArrDeque) centerSolidFraction(slice *model.ItemType) float32 {
+	t := int(slice[0][0])
+	if t < 0 {
+		t = 0
+	}
+	if t > ArrayLength {
+		t = ArrayLength
+	}
+	return c.steel1.Parameter.SolidFraction[t]
+}
@@ -0,0 +1,41 @@
+package calculator
+
+import (
+	"lz/model"
+	"testing"
+)
+
+func TestGenerateSoftReductionPlan(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	c.steel1 = &Steel{Parameter: &Parameter{}}
+	for temp := 1400; temp <= 1500; temp++ {
+		c.steel1.Parameter.SolidFraction[temp] = float32(1500-temp) / 100
+	}
+	// 下标 z 处的温度为 1500 - 5z，固相率为 0.05z
+	for z := ZLength/ZStep - 1; z >= 0; z-- {
+		c.thermalField.AddFirst(float32(1500 - 5*z))
+	}
+	c.castingMachine.Segments = []Segment{
+		{Name: "Seg_0", StartDistance: 0, EndDistance: 80, EntryThickness: 230},
+		{Name: "Seg_1", StartDistance: 80, EndDistance: 200, EntryThickness: 230},
+	}
+	c.SetSoftReductionCfg(model.SoftReductionCfg{
+		StartSolidFraction:  0.3,
+		EndSolidFraction:    0.7,
+		ReductionRate:       10,
+		MaxSegmentReduction: 0.5,
+	})
+	plan := c.GenerateSoftReductionPlan()
+	if !plan.Found || !plan.Complete || plan.StartDistance != 60 || plan.EndDistance != 140 {
+		t.Fatal("压下区间不正确:", plan.Found, plan.Complete, plan.StartDistance, plan.EndDistance)
+	}
+	if len(plan.Segments) != 2 || plan.Segments[0].Reduction != 0.2 || plan.Segments[1].Reduction != 0.5 {
+		t.Fatal("扇形段压下量不正确:", plan.Segments)
+	}
+	if plan.Segments[1].EntryGap != 229.8 || plan.Segments[1].ExitGap != 229.3 {
+		t.Fatal("建议辊缝不正确:", plan.Segments[1])
+	}
+}
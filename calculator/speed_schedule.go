@@ -0,0 +1,62 @@
+package calculator
+
+import (
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"time"
+)
+
+// 正在播放的拉速曲线
+type speedSchedule struct {
+	points  []model.SpeedPoint
+	elapsed time.Duration
+}
+
+// 开始按拉速曲线改变拉速，曲线时间从提交时刻开始按计算时间计
+// 第一个点的时间大于 0 时，从当前拉速线性过渡到第一个点
+func (c *CastingMachine) StartSpeedSchedule(points []model.SpeedPoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(points) > 0 && points[0].Time > 0 {
+		current := model.SpeedPoint{Speed: float32(c.CoolerConfig.V) * 60 / 1000}
+		points = append([]model.SpeedPoint{current}, points...)
+	}
+	c.speedSchedule = &speedSchedule{points: points}
+	log.WithField("points", len(points)).Info("开始播放拉速曲线")
+}
+
+// 停止播放拉速曲线，保持当前拉速
+func (c *CastingMachine) StopSpeedSchedule() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.speedSchedule != nil {
+		c.speedSchedule = nil
+		log.Info("停止播放拉速曲线")
+	}
+}
+
+// 按计算经过的时间推进拉速曲线
+func (c *CastingMachine) advanceSpeedSchedule(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.speedSchedule
+	if s == nil {
+		return
+	}
+	s.elapsed += d
+	t := float32(s.elapsed.Seconds())
+	last := s.points[len(s.points)-1]
+	if t >= last.Time {
+		c.setV(last.Speed)
+		c.speedSchedule = nil
+		log.WithField("v", last.Speed).Info("拉速曲线播放完成")
+		return
+	}
+	for i := 1; i < len(s.points); i++ {
+		pre, cur := s.points[i-1], s.points[i]
+		if t < cur.Time {
+			c.setV(pre.Speed + (cur.Speed-pre.Speed)*(t-pre.Time)/(cur.Time-pre.Time))
+			return
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package calculator
+
+import (
+	"lz/model"
+	"testing"
+	"time"
+)
+
+func TestSpeedSchedule(t *testing.T) {
+	c := NewCastingMachine()
+	c.SetV(1.2)
+	// 前 10s 从 1.2 升到 1.8，保持 10s 后降到 0.6
+	c.StartSpeedSchedule([]model.SpeedPoint{
+		{Time: 10, Speed: 1.8},
+		{Time: 20, Speed: 1.8},
+		{Time: 30, Speed: 0.6},
+	})
+	cases := []struct {
+		dt time.Duration
+		v  int64 // mm/s
+	}{
+		{5 * time.Second, 25},
+		{5 * time.Second, 30},
+		{10 * time.Second, 30},
+		{5 * time.Second, 20},
+		{10 * time.Second, 10},
+	}
+	for i, cs := range cases {
+		c.advanceSpeedSchedule(cs.dt)
+		if c.CoolerConfig.V != cs.v {
+			t.Fatalf("第 %d 步拉速应为 %d mm/s, 实际为 %d", i, cs.v, c.CoolerConfig.V)
+		}
+	}
+	if c.speedSchedule != nil {
+		t.Fatal("播放完成后应清除拉速曲线")
+	}
+
+	c.StartSpeedSchedule([]model.SpeedPoint{{Time: 0, Speed: 1.2}, {Time: 10, Speed: 2.4}})
+	c.advanceSpeedSchedule(5 * time.Second)
+	c.StopSpeedSchedule()
+	c.advanceSpeedSchedule(5 * time.Second)
+	if c.CoolerConfig.V != 30 {
+		t.Fatalf("停止后应保持当前拉速 30 mm/s, 实际为 %d", c.CoolerConfig.V)
+	}
+}
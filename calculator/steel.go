@@ -41,7 +41,6 @@ type Parameter struct {
 	GetQ              func(x, y, z int) float32      // 获取热流密度
 	Enthalpy2Temp     func(enthalpy float32) float32 // 通过焓值获取对应的温度
 	Temp2Enthalpy     func(temp float32) float32     // 通过温度获取焓值
-	TemperatureBottom float32                        // 温度下限
 }
 
 func NewSteel(number int, castingMachine *CastingMachine) *Steel {
@@ -203,16 +202,15 @@ func NewSteel(number int, castingMachine *CastingMachine) *Steel {
 	return &steel
 }
 
-// 获取不同冷却区对应的参数
-func (s *Steel) SetParameter(z int) {
+// 获取不同冷却区对应的温度下限，多个计算协程并发调用，不能修改共享的参数
+func (s *Steel) TemperatureBottom(z int) float32 {
 	zone := s.CastingMachine.WhichZone(z)
 	if zone == -1 {
 		log.Fatal("err: ", "冷却区超过范围")
-		return
+		return 0
 	}
 	if zone == Zone0 {
-		s.Parameter.TemperatureBottom = s.CastingMachine.CoolerConfig.NarrowSurfaceIn
-	} else {
-		s.Parameter.TemperatureBottom = s.CastingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg[zone-1].SprayWaterTemperature
+		return s.CastingMachine.CoolerConfig.NarrowSurfaceIn
 	}
+	return s.CastingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg[zone-1].SprayWaterTemperature
 }
@@ -18,7 +18,7 @@ func (e *executorBaseOnSlice) traverseSpirally(t task, c *calculatorWithArrDeque
 		if item[0][0] == -1 {
 			return
 		}
-		left, right, top, bottom := 0, Length/XStep-1, 0, Width/YStep-1 // 每个切片迭代时需要重置
+		left, right, top, bottom := 0, c.sliceRight(z), 0, Width/YStep-1 // 每个切片迭代时需要重置，调宽后的切片只计算半宽以内的节点
 		// parameter set
 		parameter = c.getParameter(z)
 		// 计算在哪一个区域
@@ -28,13 +28,13 @@ func (e *executorBaseOnSlice) traverseSpirally(t task, c *calculatorWithArrDeque
 		// 计算最外层， 逆时针
 		{
 			// 1. 三个顶点，左下方顶点仅当其外一层温度不是初始温度时才开始计算
-			c.calculatePointRB(t.deltaT, z, item, parameter, zone, electromagneticStirringFactor)
-			c.calculatePointRT(t.deltaT, z, item, parameter, zone, electromagneticStirringFactor)
+			c.calculatePointRB(t.deltaT, right, z, item, parameter, zone, electromagneticStirringFactor)
+			c.calculatePointRT(t.deltaT, right, z, item, parameter, zone, electromagneticStirringFactor)
 			c.calculatePointLT(t.deltaT, z, item, parameter, zone, electromagneticStirringFactor)
 			count += 3
 			for row := top + 1; row < bottom; row++ {
 				// [row][right]
-				c.calculatePointRA(t.deltaT, row, z, item, parameter, zone, electromagneticStirringFactor)
+				c.calculatePointRA(t.deltaT, right, row, z, item, parameter, zone, electromagneticStirringFactor)
 				count++
 			}
 			for column := right - 1; column > left; column-- {
@@ -103,6 +103,7 @@ func (e *executorBaseOnSlice) traverseSpirally(t task, c *calculatorWithArrDeque
 		}
 	})
 	log.Debug("消耗时间: ", time.Since(start), "计算的点数: ", count, "实际需要遍历的点数: ", (t.end-t.start)*(Width/YStep*Length/XStep), t.end, t.start)
+	pointsTotal.Add(float64(count))
 }
 
 // 分块遍历 - 未使用
@@ -157,6 +158,7 @@ func (e *executorBaseOnBlock) calculateCase1(t task, c *calculatorWithArrDeque)
 	}, 0, 0)
 
 	log.Debug("任务1执行时间: ", time.Since(start), "总共计算：", count, "个点")
+	pointsTotal.Add(float64(count))
 }
 
 func (e *executorBaseOnBlock) calculateCase2(t task, c *calculatorWithArrDeque) {
@@ -173,14 +175,14 @@ func (e *executorBaseOnBlock) calculateCase2(t task, c *calculatorWithArrDeque)
 		// 计算电子搅拌对传热系数的影响因子
 		electromagneticStirringFactor = c.castingMachine.GetElectromagneticStirringFactor(z)
 		// 先计算点，再计算外表面，再计算里面的点
-		c.calculatePointRT(t.deltaT, z, item, parameter, zone, electromagneticStirringFactor)
+		c.calculatePointRT(t.deltaT, Length/XStep-1, z, item, parameter, zone, electromagneticStirringFactor)
 		count++
 		for i := Length / XStep / 2; i < Length/XStep-1; i++ {
 			c.calculatePointTA(t.deltaT, i, z, item, parameter, zone, electromagneticStirringFactor)
 			count++
 		}
 		for j := Width / YStep / 2; j < Width/YStep-1; j++ {
-			c.calculatePointRA(t.deltaT, j, z, item, parameter, zone, electromagneticStirringFactor)
+			c.calculatePointRA(t.deltaT, Length/XStep-1, j, z, item, parameter, zone, electromagneticStirringFactor)
 			count++
 		}
 		for j := Width/YStep - 1 - e.edgeWidth; j < Width/YStep-1; j++ {
@@ -209,6 +211,7 @@ func (e *executorBaseOnBlock) calculateCase2(t task, c *calculatorWithArrDeque)
 		}
 	}, 0, 0)
 	log.Debug("任务2执行时间: ", time.Since(start), "总共计算：", count, "个点")
+	pointsTotal.Add(float64(count))
 }
 
 func (e *executorBaseOnBlock) calculateCase3(t task, c *calculatorWithArrDeque) {
@@ -225,14 +228,14 @@ func (e *executorBaseOnBlock) calculateCase3(t task, c *calculatorWithArrDeque)
 		// 计算电子搅拌对传热系数的影响因子
 		electromagneticStirringFactor = c.castingMachine.GetElectromagneticStirringFactor(z)
 		// 先计算点，再计算外表面，再计算里面的点
-		c.calculatePointRB(t.deltaT, z, item, parameter, zone, electromagneticStirringFactor)
+		c.calculatePointRB(t.deltaT, Length/XStep-1, z, item, parameter, zone, electromagneticStirringFactor)
 		count++
 		for i := Length / XStep / 2; i < Length/XStep-1; i++ {
 			c.calculatePointBA(t.deltaT, i, z, item, parameter, zone, electromagneticStirringFactor)
 			count++
 		}
 		for j := 1; j < Width/YStep/2; j++ {
-			c.calculatePointRA(t.deltaT, j, z, item, parameter, zone, electromagneticStirringFactor)
+			c.calculatePointRA(t.deltaT, Length/XStep-1, j, z, item, parameter, zone, electromagneticStirringFactor)
 			count++
 		}
 		for j := 1; j < 1+e.edgeWidth; j++ {
@@ -261,6 +264,7 @@ func (e *executorBaseOnBlock) calculateCase3(t task, c *calculatorWithArrDeque)
 		}
 	}, 0, 0)
 	log.Debug("任务3执行时间: ", time.Since(start), "总共计算：", count, "个点")
+	pointsTotal.Add(float64(count))
 }
 
 func (e *executorBaseOnBlock) calculateCase4(t task, c *calculatorWithArrDeque) {
@@ -313,4 +317,5 @@ func (e *executorBaseOnBlock) calculateCase4(t task, c *calculatorWithArrDeque)
 		}
 	}, 0, 0)
 	log.Debug("任务4执行时间: ", time.Since(start), "总共计算：", count, "个点")
+	pointsTotal.Add(float64(count))
 }
@@ -0,0 +1,175 @@
+package calculator
+
+import (
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"lz/validation"
+	"time"
+)
+
+const (
+	TundishSampleInterval = 10 * time.Second // 浇铸温度历史的采样间隔，计算时间
+	MaxTundishSamples     = 8640             // 最多保存的采样点数，按 10s 间隔为 24h
+)
+
+// 中间包温度模型：播放温度曲线，或按降温速率下降
+type tundish struct {
+	points      []model.TundishPoint
+	coolingRate float32 // ℃/min
+	elapsed     time.Duration
+}
+
+// 浇铸温度历史上的一个采样点，Time 为计算开始后经过的计算时间
+type TundishSample struct {
+	Time        float32 `json:"time"`        // s
+	Temperature float32 `json:"temperature"` // ℃
+	LadleChange bool    `json:"ladle_change"`
+}
+
+// 浇铸温度历史
+type tundishHistory struct {
+	elapsed    time.Duration
+	lastSample time.Duration
+	samples    []TundishSample
+}
+
+// 过热度历史上的一个点
+type SuperheatSample struct {
+	TundishSample
+	Superheat float32 `json:"superheat"` // 浇铸温度与液相线温度之差
+}
+
+// 过热度历史
+type SuperheatHistory struct {
+	LiquidPhaseTemperature float32           `json:"liquid_phase_temperature"`
+	Samples                []SuperheatSample `json:"samples"`
+}
+
+// 开始按中间包温度模型改变浇铸温度，曲线时间从提交时刻开始按计算时间计
+// 第一个点的时间大于 0 时，从当前浇铸温度线性过渡到第一个点
+func (c *CastingMachine) StartTundish(cfg model.TundishCfg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	points := cfg.Points
+	if len(points) > 0 && points[0].Time > 0 {
+		current := model.TundishPoint{Temperature: c.CoolerConfig.StartTemperature}
+		points = append([]model.TundishPoint{current}, points...)
+	}
+	c.tundish = &tundish{points: points, coolingRate: cfg.CoolingRate}
+	log.WithFields(log.Fields{"points": len(points), "cooling_rate": cfg.CoolingRate}).Info("开始中间包温度模型")
+}
+
+// 停止中间包温度模型，保持当前浇铸温度
+func (c *CastingMachine) StopTundish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tundish != nil {
+		c.tundish = nil
+		log.Info("停止中间包温度模型")
+	}
+}
+
+// 换包，浇铸温度跳变到新钢包的温度
+// 正在播放的温度曲线不再适用，之后按降温速率从新的温度下降
+func (c *CastingMachine) ChangeLadle(ladle model.LadleChange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CoolerConfig.StartTemperature = ladle.Temperature
+	if c.tundish != nil {
+		c.tundish.points = nil
+	}
+	c.history.record(ladle.Temperature, true)
+	log.WithField("temperature", ladle.Temperature).Info("换包")
+}
+
+// 按计算经过的时间推进中间包温度模型，返回新切片的浇铸温度
+// 按降温速率下降到 floor 时停在 floor 并停止模型，避免浇铸温度无限下降
+func (c *CastingMachine) advanceTundish(d time.Duration, floor float32) float32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t := c.tundish; t != nil {
+		t.elapsed += d
+		if len(t.points) > 0 {
+			c.CoolerConfig.StartTemperature = t.temperature()
+			if float32(t.elapsed.Seconds()) >= t.points[len(t.points)-1].Time {
+				t.points = nil
+				log.WithField("temperature", c.CoolerConfig.StartTemperature).Info("中间包温度曲线播放完成")
+			}
+		} else if next := c.CoolerConfig.StartTemperature - t.coolingRate*float32(d.Seconds())/60; next > floor {
+			c.CoolerConfig.StartTemperature = next
+		} else {
+			// 已经低于下限的温度（如换包温度）保持不变，不向上修正
+			if c.CoolerConfig.StartTemperature > floor {
+				c.CoolerConfig.StartTemperature = floor
+			}
+			c.tundish = nil
+			log.WithFields(log.Fields{"temperature": c.CoolerConfig.StartTemperature, "floor": floor}).Warn("浇铸温度降到下限，停止中间包温度模型")
+		}
+	}
+	c.history.elapsed += d
+	if c.history.samples == nil || c.history.elapsed-c.history.lastSample >= TundishSampleInterval {
+		c.history.record(c.CoolerConfig.StartTemperature, false)
+	}
+	return c.CoolerConfig.StartTemperature
+}
+
+// 当前时刻温度曲线上的温度
+func (t *tundish) temperature() float32 {
+	s := float32(t.elapsed.Seconds())
+	last := t.points[len(t.points)-1]
+	if s >= last.Time {
+		return last.Temperature
+	}
+	for i := 1; i < len(t.points); i++ {
+		pre, cur := t.points[i-1], t.points[i]
+		if s < cur.Time {
+			return pre.Temperature + (cur.Temperature-pre.Temperature)*(s-pre.Time)/(cur.Time-pre.Time)
+		}
+	}
+	return last.Temperature
+}
+
+func (h *tundishHistory) record(temperature float32, ladleChange bool) {
+	if len(h.samples) >= MaxTundishSamples {
+		copy(h.samples, h.samples[1:])
+		h.samples = h.samples[:len(h.samples)-1]
+	}
+	h.samples = append(h.samples, TundishSample{
+		Time:        float32(h.elapsed.Seconds()),
+		Temperature: temperature,
+		LadleChange: ladleChange,
+	})
+	h.lastSample = h.elapsed
+}
+
+// 获取浇铸温度历史
+func (c *CastingMachine) GetTundishHistory() []TundishSample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]TundishSample{}, c.history.samples...)
+}
+
+// 中间包降温的下限，取液相线温度和浇铸温度下限中较高的一个
+func (c *calculatorWithArrDeque) tundishFloor() float32 {
+	floor := float32(validation.MinCastingTemperature)
+	if c.steel1 != nil && c.steel1.LiquidPhaseTemperature > floor {
+		floor = c.steel1.LiquidPhaseTemperature
+	}
+	return floor
+}
+
+// 生成过热度历史，过热度按当前钢种的液相线温度计算
+func (c *calculatorWithArrDeque) GenerateSuperheatHistory() *SuperheatHistory {
+	samples := c.castingMachine.GetTundishHistory()
+	history := &SuperheatHistory{Samples: make([]SuperheatSample, len(samples))}
+	if c.steel1 != nil {
+		history.LiquidPhaseTemperature = c.steel1.LiquidPhaseTemperature
+	}
+	for i, sample := range samples {
+		history.Samples[i] = SuperheatSample{TundishSample: sample}
+		if c.steel1 != nil {
+			history.Samples[i].Superheat = sample.Temperature - history.LiquidPhaseTemperature
+		}
+	}
+	return history
+}
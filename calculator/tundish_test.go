@@ -0,0 +1,89 @@
+package calculator
+
+import (
+	"lz/model"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTundish(t *testing.T) {
+	c := NewCastingMachine()
+	c.SetStartTemperature(1560)
+	// 前 10s 从 1560 降到 1550，之后按 6℃/min 降温
+	c.StartTundish(model.TundishCfg{
+		Points:      []model.TundishPoint{{Time: 10, Temperature: 1550}},
+		CoolingRate: 6,
+	})
+	cases := []struct {
+		dt          time.Duration
+		temperature float32
+	}{
+		{5 * time.Second, 1555},
+		{5 * time.Second, 1550},
+		{10 * time.Second, 1549},
+	}
+	for i, cs := range cases {
+		if got := c.advanceTundish(cs.dt, 1400); math.Abs(float64(got-cs.temperature)) > 1e-3 {
+			t.Fatalf("第 %d 步浇铸温度应为 %.1f, 实际为 %.3f", i, cs.temperature, got)
+		}
+	}
+
+	// 换包后温度跳变，并继续按降温速率下降
+	c.ChangeLadle(model.LadleChange{Temperature: 1565})
+	if got := c.advanceTundish(10*time.Second, 1400); math.Abs(float64(got-1564)) > 1e-3 {
+		t.Fatal("换包后浇铸温度不正确:", got)
+	}
+	c.StopTundish()
+	if got := c.advanceTundish(10*time.Second, 1400); math.Abs(float64(got-1564)) > 1e-3 {
+		t.Fatal("停止后浇铸温度应保持不变:", got)
+	}
+
+	samples := c.GetTundishHistory()
+	ladleChanges := 0
+	for _, sample := range samples {
+		if sample.LadleChange {
+			ladleChanges++
+			if sample.Temperature != 1565 || sample.Time != 20 {
+				t.Fatalf("换包采样点不正确: %+v", sample)
+			}
+		}
+	}
+	if ladleChanges != 1 || samples[len(samples)-1].Time != 40 {
+		t.Fatalf("浇铸温度历史不正确: %+v", samples)
+	}
+}
+
+func TestTundishFloor(t *testing.T) {
+	c := NewCastingMachine()
+	c.SetStartTemperature(1530)
+	c.StartTundish(model.TundishCfg{CoolingRate: 60})
+	// 每分钟降 60℃，降到 1500 时停止
+	if got := c.advanceTundish(20*time.Second, 1500); got != 1510 {
+		t.Fatal("未到下限时应按降温速率下降:", got)
+	}
+	if got := c.advanceTundish(20*time.Second, 1500); got != 1500 {
+		t.Fatal("应停在下限:", got)
+	}
+	if got := c.advanceTundish(time.Minute, 1500); got != 1500 {
+		t.Fatal("到下限后应停止中间包温度模型:", got)
+	}
+
+	// 换包温度低于下限时不向上修正
+	c.StartTundish(model.TundishCfg{CoolingRate: 60})
+	c.ChangeLadle(model.LadleChange{Temperature: 1490})
+	if got := c.advanceTundish(time.Second, 1500); got != 1490 {
+		t.Fatal("低于下限的浇铸温度应保持不变:", got)
+	}
+}
+
+func TestSuperheatHistory(t *testing.T) {
+	c := &calculatorWithArrDeque{castingMachine: NewCastingMachine()}
+	c.castingMachine.SetStartTemperature(1540)
+	c.castingMachine.advanceTundish(time.Second, 1400)
+	c.steel1 = &Steel{LiquidPhaseTemperature: 1515}
+	history := c.GenerateSuperheatHistory()
+	if len(history.Samples) != 1 || history.Samples[0].Superheat != 25 {
+		t.Fatalf("过热度历史不正确: %+v", history)
+	}
+}
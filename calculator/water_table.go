@@ -0,0 +1,110 @@
+package calculator
+
+import (
+	"errors"
+	log "github.com/sirupsen/logrus"
+	"lz/model"
+	"math"
+)
+
+var ErrInvalidCoolingMode = errors.New("二冷控制模式不存在")
+
+// 设置水表，水表模式下在下一个计算周期生效
+func (c *calculatorWithArrDeque) SetWaterTables(tables []model.WaterTable) {
+	c.mu.Lock()
+	c.waterTables = tables
+	c.tableV = -1
+	c.mu.Unlock()
+	log.WithField("tables", len(tables)).Info("水表已更新")
+}
+
+// 切换二冷控制模式：手动、水表、动态控制
+func (c *calculatorWithArrDeque) SetCoolingMode(mode string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch mode {
+	case model.CoolingModeManual, model.CoolingModeTable:
+		c.dynamicCoolingCfg.Enabled = false
+	case model.CoolingModeDynamic:
+		c.dynamicCoolingCfg.Enabled = true
+		c.controller = coolingController{}
+	default:
+		return ErrInvalidCoolingMode
+	}
+	c.coolingMode = mode
+	c.tableV = -1
+	log.WithField("mode", mode).Info("切换二冷控制模式")
+	return nil
+}
+
+// 获取当前二冷控制模式
+func (c *calculatorWithArrDeque) GetCoolingMode() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.coolingMode
+}
+
+// 水表模式下拉速变化后按水表重新计算二冷水量，需持有 c.mu，读写二冷水量时持有铸机的锁
+func (c *calculatorWithArrDeque) applyWaterTables() {
+	c.castingMachine.mu.Lock()
+	defer c.castingMachine.mu.Unlock()
+	v := c.castingMachine.CoolerConfig.V
+	if c.coolingMode != model.CoolingModeTable || c.steel1 == nil || v == c.tableV {
+		return
+	}
+	c.tableV = v
+	speed := float32(v) * 60 / 1000 // mm/s -> m/min
+	coolingZoneCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg
+	waterCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+	for _, table := range c.waterTables {
+		if table.SteelValue != c.steel1.Number {
+			continue
+		}
+		for i, zone := range coolingZoneCfg {
+			if zone.ZoneName != table.ZoneName || i >= len(waterCfg) {
+				continue
+			}
+			old := waterCfg[i].InnerArcWaterVolume
+			volume := lookupWaterTable(table, speed)
+			scaleZoneVolume(&waterCfg[i], old, volume)
+			log.WithFields(log.Fields{"zone": zone.ZoneName, "speed": speed, "old": old, "volume": volume}).Info("按水表设置二冷水量")
+		}
+	}
+}
+
+// 查水表得到拉速 speed 对应的内弧水量
+func lookupWaterTable(table model.WaterTable, speed float32) float32 {
+	if table.Speed.Top > table.Speed.Bottom {
+		if speed < table.Speed.Bottom {
+			speed = table.Speed.Bottom
+		}
+		if speed > table.Speed.Top {
+			speed = table.Speed.Top
+		}
+	}
+	if table.Speed.Step > 0 {
+		speed = float32(math.Round(float64(speed/table.Speed.Step))) * table.Speed.Step
+	}
+	var volume float32
+	points := table.Points
+	switch {
+	case len(points) == 0:
+		volume = table.A*speed*speed + table.B*speed + table.C
+	case speed <= points[0].Speed:
+		volume = points[0].Volume
+	case speed >= points[len(points)-1].Speed:
+		volume = points[len(points)-1].Volume
+	default:
+		for i := 1; i < len(points); i++ {
+			if speed <= points[i].Speed {
+				pre, cur := points[i-1], points[i]
+				volume = pre.Volume + (cur.Volume-pre.Volume)*(speed-pre.Speed)/(cur.Speed-pre.Speed)
+				break
+			}
+		}
+	}
+	if volume < 0 {
+		volume = 0
+	}
+	return volume
+}
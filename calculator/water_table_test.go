@@ -0,0 +1,68 @@
+package calculator
+
+import (
+	"lz/model"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLookupWaterTable(t *testing.T) {
+	table := model.WaterTable{
+		Speed: model.Speed2Water{Bottom: 0.8, Top: 2.0},
+		Points: []model.WaterPoint{
+			{Speed: 1.0, Volume: 100},
+			{Speed: 2.0, Volume: 200},
+		},
+	}
+	cases := []struct {
+		speed, volume float32
+	}{
+		{0.5, 100}, // 低于适用范围取下限，再低于第一个点取第一个点
+		{1.5, 150},
+		{3.0, 200},
+	}
+	for _, cs := range cases {
+		if v := lookupWaterTable(table, cs.speed); v != cs.volume {
+			t.Fatalf("拉速 %v 对应水量应为 %v, 实际为 %v", cs.speed, cs.volume, v)
+		}
+	}
+	quadratic := model.WaterTable{A: 10, B: 20, C: 30, Speed: model.Speed2Water{Step: 0.5}}
+	if v := lookupWaterTable(quadratic, 1.1); v != 10+20+30 {
+		t.Fatalf("按步长取整后拉速为 1.0, 水量应为 60, 实际为 %v", v)
+	}
+}
+
+func TestWaterTableMode(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	c.steel1 = &Steel{Number: 1, Parameter: &Parameter{}}
+	c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.CoolingZoneCfg = []model.CoolingZone{{ZoneName: "1 Subarea"}}
+	c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{
+		{InnerArcWaterVolume: 100, NarrowSideWaterVolume: 50},
+	}
+	c.SetWaterTables([]model.WaterTable{{SteelValue: 1, ZoneName: "1 Subarea", B: 100}})
+	waterCfg := c.castingMachine.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg
+
+	c.castingMachine.SetV(1.2)
+	c.controlCooling(time.Second)
+	if waterCfg[0].InnerArcWaterVolume != 100 {
+		t.Fatal("手动模式下不应按水表修改水量")
+	}
+	if err := c.SetCoolingMode(model.CoolingModeTable); err != nil {
+		t.Fatal(err)
+	}
+	c.controlCooling(time.Second)
+	// V = 20 mm/s -> 1.2 m/min
+	if math.Abs(float64(waterCfg[0].InnerArcWaterVolume-120)) > 1e-3 || math.Abs(float64(waterCfg[0].NarrowSideWaterVolume-60)) > 1e-3 {
+		t.Fatalf("水表模式下水量不正确: %+v", waterCfg[0])
+	}
+	if err := c.SetCoolingMode("auto"); err != ErrInvalidCoolingMode {
+		t.Fatal("不存在的模式应该被拒绝:", err)
+	}
+	if err := c.SetCoolingMode(model.CoolingModeDynamic); err != nil || !c.GenerateDynamicCoolingData().Enabled {
+		t.Fatal("切换到动态控制模式后应启用动态二冷控制")
+	}
+}
@@ -0,0 +1,126 @@
+package calculator
+
+import (
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// 在线调宽过程，结晶器窄面在 duration 内从 from 线性移动到 to
+type widthChange struct {
+	from     float32
+	to       float32
+	elapsed  time.Duration
+	duration time.Duration
+}
+
+// 开始调宽，宽度为宽面长度，单位mm
+// 温度场网格仍按 env_set 时的宽度划分，新切片记录调宽后的宽度，计算时只计算切片半宽以内的节点
+func (c *CastingMachine) StartWidthChange(width int, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.widthChange = &widthChange{
+		from:     c.MoldWidth,
+		to:       float32(width),
+		duration: duration,
+	}
+	log.WithFields(log.Fields{
+		"from":     c.MoldWidth,
+		"to":       width,
+		"duration": duration,
+	}).Info("开始调宽")
+}
+
+// 获取当前结晶器宽度以及是否正在调宽
+func (c *CastingMachine) GetMoldWidth() (float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.MoldWidth, c.widthChange != nil
+}
+
+// 按计算经过的时间推进调宽过程，返回当前结晶器宽度
+func (c *CastingMachine) advanceWidthChange(d time.Duration) float32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := c.widthChange
+	if w == nil {
+		return c.MoldWidth
+	}
+	w.elapsed += d
+	if w.elapsed >= w.duration {
+		c.MoldWidth = w.to
+		c.widthChange = nil
+		log.WithField("width", c.MoldWidth).Info("调宽完成")
+		return c.MoldWidth
+	}
+	c.MoldWidth = w.from + (w.to-w.from)*float32(w.elapsed)/float32(w.duration)
+	return c.MoldWidth
+}
+
+// 调宽过渡区，距弯月面的距离
+type WidthTransition struct {
+	StartDistance float32 `json:"start_distance"`
+	EndDistance   float32 `json:"end_distance"`
+	FromWidth     float32 `json:"from_width"` // 过渡区出口侧的宽度
+	ToWidth       float32 `json:"to_width"`   // 过渡区入口侧的宽度
+}
+
+// 查找切片宽度发生变化的区域，没有变化时返回 nil
+func (c *calculatorWithArrDeque) widthTransition() *WidthTransition {
+	start, end := -1, -1
+	for z := 1; z < c.metas.size(); z++ {
+		cur, pre := c.metas.get(z).Width, c.metas.get(z-1).Width
+		if cur == 0 || pre == 0 || cur == pre {
+			continue
+		}
+		if start == -1 {
+			start = z - 1
+		}
+		end = z
+	}
+	if start == -1 {
+		return nil
+	}
+	return &WidthTransition{
+		StartDistance: float32(start * ZStep),
+		EndDistance:   float32(end * ZStep),
+		FromWidth:     c.metas.get(end).Width,
+		ToWidth:       c.metas.get(start).Width,
+	}
+}
+
+// 切片窄面所在的列，没有记录宽度的切片使用网格的宽度
+// 至少保留三列节点，保证窄面和内部节点都能计算
+func (c *calculatorWithArrDeque) sliceRight(z int) int {
+	width := c.metas.get(z).Width
+	if width <= 0 {
+		return Length/XStep - 1
+	}
+	right := int(width/2)/XStep - 1
+	if right < 2 {
+		return 2
+	}
+	if right > Length/XStep-1 {
+		return Length/XStep - 1
+	}
+	return right
+}
+
+// 窄于网格的切片，半宽以外的节点不属于铸坯，用窄面温度填充
+// 这些节点不参与计算，填充后读取网格边缘的表面温度、云图等仍得到切片窄面的温度
+func (c *calculatorWithArrDeque) maskNarrowedSlices() {
+	for z := 0; z < c.Field.Size(); z++ {
+		right := c.sliceRight(z)
+		if right == Length/XStep-1 {
+			continue
+		}
+		slice := c.Field.GetSlice(z)
+		if slice[0][0] == -1 {
+			continue
+		}
+		for y := 0; y < Width/YStep; y++ {
+			for x := right + 1; x < Length/XStep; x++ {
+				slice[y][x] = slice[y][right]
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdvanceWidthChange(t *testing.T) {
+	c := NewCastingMachine()
+	c.MoldWidth = 1200
+	c.StartWidthChange(1000, 10*time.Second)
+
+	if w := c.advanceWidthChange(5 * time.Second); w != 1100 {
+		t.Fatalf("调宽一半时宽度应为 1100, 实际为 %v", w)
+	}
+	if _, changing := c.GetMoldWidth(); !changing {
+		t.Fatal("调宽未完成")
+	}
+	if w := c.advanceWidthChange(6 * time.Second); w != 1000 {
+		t.Fatalf("调宽完成后宽度应为 1000, 实际为 %v", w)
+	}
+	if _, changing := c.GetMoldWidth(); changing {
+		t.Fatal("调宽应已完成")
+	}
+}
+
+func TestWidthTransition(t *testing.T) {
+	c := &calculatorWithArrDeque{metas: newSliceMetaQueue(10)}
+	for _, w := range []float32{1200, 1200, 1200, 1150, 1100, 1100} {
+		c.metas.addFirst(sliceMeta{Width: w})
+	}
+	tr := c.widthTransition()
+	if tr == nil {
+		t.Fatal("应存在调宽过渡区")
+	}
+	if tr.StartDistance != float32(1*ZStep) || tr.EndDistance != float32(3*ZStep) {
+		t.Fatalf("过渡区位置错误: %+v", tr)
+	}
+	if tr.FromWidth != 1200 || tr.ToWidth != 1100 {
+		t.Fatalf("过渡区宽度错误: %+v", tr)
+	}
+
+	c.metas.removeLast()
+	c.metas.removeLast()
+	c.metas.removeLast()
+	if c.widthTransition() == nil || c.metas.size() != 3 {
+		t.Fatal("删除切片后过渡区应仍在")
+	}
+}
+
+func TestSliceMetaQueueWrap(t *testing.T) {
+	q := newSliceMetaQueue(3)
+	// 多次增删后环形数组绕回，顺序仍与温度场一致
+	for i := 1; i <= 7; i++ {
+		if q.size() == 3 {
+			q.removeLast()
+		}
+		q.addFirst(sliceMeta{Width: float32(i)})
+	}
+	for z, want := range []float32{7, 6, 5} {
+		if w := q.get(z).Width; w != want {
+			t.Fatalf("下标 %d 的切片宽度应为 %v, 实际为 %v", z, want, w)
+		}
+	}
+	// 队列已满时丢弃结尾的切片
+	q.addFirst(sliceMeta{Width: 8})
+	if q.size() != 3 || q.get(0).Width != 8 || q.get(2).Width != 6 {
+		t.Fatalf("队列已满时应丢弃结尾的切片: %v %v", q.size(), q.get(2))
+	}
+	if q.get(3).Width != 0 || q.get(-1).Width != 0 {
+		t.Fatal("越界时应返回空切片信息")
+	}
+}
+
+func TestMaskNarrowedSlices(t *testing.T) {
+	ZLength = 200
+	Length = 50
+	Width = 20
+	c := NewCalculatorWithArrDeque(nil)
+	defer c.Close()
+	for _, w := range []float32{0, 100, 60} {
+		c.thermalField.AddFirst(1500)
+		c.metas.addFirst(sliceMeta{Width: w})
+	}
+	if c.sliceRight(0) != 5 || c.sliceRight(1) != Length/XStep-1 || c.sliceRight(2) != Length/XStep-1 {
+		t.Fatalf("切片窄面所在的列不正确: %d %d %d", c.sliceRight(0), c.sliceRight(1), c.sliceRight(2))
+	}
+	narrowed, full := c.Field.GetSlice(0), c.Field.GetSlice(2)
+	for y := 0; y < Width/YStep; y++ {
+		narrowed[y][5] = 1400 - float32(y)
+		full[y][5] = 1400
+	}
+	c.maskNarrowedSlices()
+	for y := 0; y < Width/YStep; y++ {
+		for x := 6; x < Length/XStep; x++ {
+			if narrowed[y][x] != 1400-float32(y) {
+				t.Fatalf("半宽以外的节点应为窄面温度: [%d][%d] %v", y, x, narrowed[y][x])
+			}
+			if full[y][x] != 1500 {
+				t.Fatalf("没有调宽的切片不应修改: [%d][%d] %v", y, x, full[y][x])
+			}
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package caster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"lz/model"
+	"lz/validation"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ext        = ".json"
+	historyDir = "history" // 历史版本目录，位于铸机目录下
+)
+
+var (
+	ErrNotFound      = errors.New("铸机不存在")
+	ErrAlreadyExists = errors.New("铸机已存在")
+	ErrInvalidName   = errors.New("铸机名称不合法")
+	ErrNoNozzleCfg   = errors.New("铸机未配置喷嘴布置")
+)
+
+// 铸机库，每台铸机对应 home 目录下的一个 json 文件
+type Repository struct {
+	home string
+	mu   sync.Mutex
+}
+
+func NewRepository(home string) *Repository {
+	return &Repository{
+		home: home,
+	}
+}
+
+// 获取所有铸机名称
+func (r *Repository) List() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	files, err := ioutil.ReadDir(r.home)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ext {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(f.Name(), ext))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// 读取并解析铸机
+func (r *Repository) Get(name string) (*model.Caster, error) {
+	if !validName(name) {
+		return nil, ErrInvalidName
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.read(r.path(name))
+}
+
+// 新建铸机
+func (r *Repository) Create(caster *model.Caster) error {
+	if !validName(caster.Name) {
+		return ErrInvalidName
+	}
+	if err := r.validate(caster); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := os.Stat(r.path(caster.Name)); err == nil {
+		return ErrAlreadyExists
+	}
+	// 删除后重新创建的铸机接着历史版本编号，不覆盖已删除铸机的历史
+	latest, err := r.latestVersion(caster.Name)
+	if err != nil {
+		return err
+	}
+	caster.Version = latest + 1
+	caster.UpdatedAt = time.Now().Format(time.RFC3339)
+	return r.write(r.path(caster.Name), caster)
+}
+
+// 更新铸机，旧版本保存到历史目录
+func (r *Repository) Update(caster *model.Caster) error {
+	if !validName(caster.Name) {
+		return ErrInvalidName
+	}
+	if err := r.validate(caster); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, err := r.read(r.path(caster.Name))
+	if err != nil {
+		return err
+	}
+	if err = r.archive(old); err != nil {
+		return err
+	}
+	caster.Version = old.Version + 1
+	caster.UpdatedAt = time.Now().Format(time.RFC3339)
+	return r.write(r.path(caster.Name), caster)
+}
+
+// 删除铸机，删除前保存到历史目录
+func (r *Repository) Delete(name string) error {
+	if !validName(name) {
+		return ErrInvalidName
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, err := r.read(r.path(name))
+	if err != nil {
+		return err
+	}
+	if err = r.archive(old); err != nil {
+		return err
+	}
+	return os.Remove(r.path(name))
+}
+
+// 获取铸机的历史版本，按版本号升序
+func (r *Repository) History(name string) ([]model.CasterVersion, error) {
+	if !validName(name) {
+		return nil, ErrInvalidName
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	files, err := ioutil.ReadDir(filepath.Join(r.home, historyDir, name))
+	if os.IsNotExist(err) {
+		return []model.CasterVersion{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]model.CasterVersion, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ext {
+			continue
+		}
+		caster, err := r.read(filepath.Join(r.home, historyDir, name, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, model.CasterVersion{Version: caster.Version, UpdatedAt: caster.UpdatedAt})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+	return versions, nil
+}
+
+// 获取铸机的某个历史版本
+func (r *Repository) GetVersion(name string, version int) (*model.Caster, error) {
+	if !validName(name) {
+		return nil, ErrInvalidName
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.read(r.historyPath(name, version))
+}
+
+// 获取铸机的喷嘴布置，优先使用铸机内的配置，其次读取 NozzleFile
+func (r *Repository) Nozzle(caster *model.Caster) (*model.NozzleCfg, error) {
+	if caster.NozzleCfg != nil {
+		nozzleCfg := *caster.NozzleCfg
+		return &nozzleCfg, nil
+	}
+	if caster.NozzleFile == "" {
+		return nil, ErrNoNozzleCfg
+	}
+	// 喷嘴布置文件只能位于铸机目录下
+	if !filepath.IsLocal(caster.NozzleFile) {
+		return nil, fmt.Errorf("喷嘴布置文件路径不合法: %s", caster.NozzleFile)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(r.home, caster.NozzleFile))
+	if err != nil {
+		return nil, err
+	}
+	var nozzleCfg model.NozzleCfg
+	if err = json.Unmarshal(data, &nozzleCfg); err != nil {
+		return nil, fmt.Errorf("喷嘴布置文件解析失败 %s: %w", caster.NozzleFile, err)
+	}
+	return &nozzleCfg, nil
+}
+
+// 校验铸机定义以及铸机与喷嘴布置是否一致
+func (r *Repository) validate(caster *model.Caster) error {
+	errs := validation.Caster(caster)
+	nozzleCfg, err := r.Nozzle(caster)
+	if err == ErrNoNozzleCfg {
+		return errs.Err()
+	}
+	if err != nil {
+		return err
+	}
+	if caster.NozzleCfg == nil {
+		errs = append(errs, validation.Nozzle("nozzle_file", *nozzleCfg, len(caster.CoolingZone))...)
+	}
+	errs = append(errs, validation.CasterNozzle(caster, *nozzleCfg)...)
+	return errs.Err()
+}
+
+func (r *Repository) path(name string) string {
+	return filepath.Join(r.home, name+ext)
+}
+
+func (r *Repository) historyPath(name string, version int) string {
+	return filepath.Join(r.home, historyDir, name, "v"+strconv.Itoa(version)+ext)
+}
+
+// 历史目录中最大的版本号，没有历史版本时返回 0
+func (r *Repository) latestVersion(name string) (int, error) {
+	files, err := ioutil.ReadDir(filepath.Join(r.home, historyDir, name))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	latest := 0
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ext {
+			continue
+		}
+		version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(f.Name(), "v"), ext))
+		if err == nil && version > latest {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+func (r *Repository) read(path string) (*model.Caster, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var caster model.Caster
+	if err = json.Unmarshal(data, &caster); err != nil {
+		return nil, fmt.Errorf("铸机文件解析失败 %s: %w", path, err)
+	}
+	if caster.Name == "" {
+		caster.Name = strings.TrimSuffix(filepath.Base(path), ext)
+	}
+	return &caster, nil
+}
+
+func (r *Repository) write(path string, caster *model.Caster) error {
+	data, err := json.MarshalIndent(caster, "", "  ")
+	if err != nil {
+		return err
+	}
+	// 先写临时文件再重命名，避免写入一半时文件损坏
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (r *Repository) archive(caster *model.Caster) error {
+	if err := os.MkdirAll(filepath.Join(r.home, historyDir, caster.Name), 0755); err != nil {
+		return err
+	}
+	return r.write(r.historyPath(caster.Name, caster.Version), caster)
+}
+
+// 名称即文件名，不允许包含路径
+func validName(name string) bool {
+	if name == "" || name == historyDir || strings.HasPrefix(name, ".") {
+		return false
+	}
+	return !strings.ContainsAny(name, `/\:`)
+}
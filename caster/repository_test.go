@@ -0,0 +1,162 @@
+package caster
+
+import (
+	"errors"
+	"lz/model"
+	"lz/validation"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestCaster(name string) *model.Caster {
+	return &model.Caster{
+		Name: name,
+		Coordinate: model.Coordinate{
+			MdLength: 950,
+			Width:    230,
+			Length:   1260,
+			ZLength:  31860,
+		},
+		CoolingZone: []model.CasterCoolingZone{
+			{ZoneName: "1 Subarea", Start: 1, End: 2},
+			{ZoneName: "2 Subarea", Start: 3, End: 3},
+		},
+		SecondaryCoolingZone: []model.Roller{
+			{RollerNum: 1, Distance: 930},
+			{RollerNum: 2, Distance: 1083},
+			{RollerNum: 3, Distance: 1236},
+		},
+	}
+}
+
+func TestRepository(t *testing.T) {
+	r := NewRepository(t.TempDir())
+	if err := r.Create(newTestCaster("c1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Create(newTestCaster("c1")); err != ErrAlreadyExists {
+		t.Fatal("重复新建铸机应该失败:", err)
+	}
+	c := newTestCaster("c1")
+	c.Coordinate.Width = 250
+	if err := r.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Get("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != 2 || got.Coordinate.Width != 250 {
+		t.Fatal("更新后的铸机不正确:", got.Version, got.Coordinate.Width)
+	}
+	names, err := r.List()
+	if err != nil || len(names) != 1 || names[0] != "c1" {
+		t.Fatal("铸机列表不正确:", names, err)
+	}
+	if err = r.Delete("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Get("c1"); err != ErrNotFound {
+		t.Fatal("删除后铸机应该不存在:", err)
+	}
+	versions, err := r.History("c1")
+	if err != nil || len(versions) != 2 || versions[0].Version != 1 || versions[1].Version != 2 {
+		t.Fatal("历史版本不正确:", versions, err)
+	}
+	old, err := r.GetVersion("c1", 1)
+	if err != nil || old.Coordinate.Width != 230 {
+		t.Fatal("历史版本内容不正确:", old, err)
+	}
+
+	// 重新创建后接着历史版本编号，更新时不覆盖已删除铸机的历史
+	if err = r.Create(newTestCaster("c1")); err != nil {
+		t.Fatal(err)
+	}
+	c = newTestCaster("c1")
+	c.Coordinate.Width = 260
+	if err = r.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = r.Get("c1"); err != nil || got.Version != 4 {
+		t.Fatal("重新创建的铸机版本号不正确:", got, err)
+	}
+	versions, err = r.History("c1")
+	if err != nil || len(versions) != 3 || versions[2].Version != 3 {
+		t.Fatal("重新创建后的历史版本不正确:", versions, err)
+	}
+	if old, err = r.GetVersion("c1", 1); err != nil || old.Coordinate.Width != 230 {
+		t.Fatal("已删除铸机的历史版本不应被覆盖:", old, err)
+	}
+}
+
+func TestRepositoryInvalid(t *testing.T) {
+	r := NewRepository(t.TempDir())
+	if _, err := r.Get("../caster"); err != ErrInvalidName {
+		t.Fatal("非法名称应该被拒绝:", err)
+	}
+	c := newTestCaster("c2")
+	c.CoolingZone[1].End = 10
+	if err := r.Create(c); err == nil {
+		t.Fatal("辊子下标越界应该被拒绝")
+	}
+}
+
+// 读取仓库自带的铸机配置
+func TestRepositoryDefaultCaster(t *testing.T) {
+	r := NewRepository(filepath.Join("..", "conf", "casters"))
+	c, err := r.Get("caster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = validation.Caster(c).Err(); err != nil {
+		t.Fatal(err)
+	}
+	nozzleCfg, err := r.Nozzle(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = validation.CasterNozzle(c, *nozzleCfg).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.SecondaryCoolingZone) == 0 || len(c.CoolingZone) == 0 {
+		t.Fatal("铸机配置解析不完整")
+	}
+}
+
+func TestRepositoryNozzle(t *testing.T) {
+	r := NewRepository(t.TempDir())
+	c := newTestCaster("c3")
+	if _, err := r.Nozzle(c); err != ErrNoNozzleCfg {
+		t.Fatal("未配置喷嘴布置时应返回 ErrNoNozzleCfg:", err)
+	}
+	for _, file := range []string{"../nozzle.json", "a/../../nozzle.json", "/etc/nozzle.json"} {
+		c.NozzleFile = file
+		if _, err := r.Nozzle(c); err == nil || errors.Is(err, os.ErrNotExist) {
+			t.Fatal("铸机目录外的喷嘴布置文件应该被拒绝:", file, err)
+		}
+	}
+	// 以 .. 开头的文件名仍在铸机目录下
+	c.NozzleFile = "..nozzles.json"
+	if err := os.WriteFile(filepath.Join(r.home, c.NozzleFile), []byte(`{"wide_items": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Nozzle(c); err != nil {
+		t.Fatal("铸机目录下以 .. 开头的喷嘴布置文件应该被接受:", err)
+	}
+	c.NozzleFile = ""
+	c.NozzleCfg = &model.NozzleCfg{
+		WideItems: []model.WideItem{
+			{RollerNum: 1, CoolingZone: 1, Distance: 930, RollerDistance: 153},
+			{RollerNum: 2, CoolingZone: 2, Distance: 1083, RollerDistance: 153},
+			{RollerNum: 3, CoolingZone: 2, Distance: 1236, RollerDistance: 153},
+		},
+	}
+	if err := r.Create(c); err == nil {
+		t.Fatal("喷嘴所在冷却区与冷却区辊子范围不一致时应该被拒绝")
+	}
+	c.NozzleCfg.WideItems[1].CoolingZone = 1
+	if err := r.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -9,6 +9,7 @@ var AppConfig *Config
 
 type Config struct {
 	Port                  string
+	GrpcPort              string // gRPC 端口，为空时不启动 gRPC 服务
 	ReadBufferSize        int
 	WriteBufferSize       int
 	Mode                  string
@@ -16,6 +17,10 @@ type Config struct {
 	PhysicalParameterFile string
 	NozzleConfigFile      string
 	CasterHomePath        string
+	HistoryPath           string // 历史数据目录，为空时不记录历史数据
+	HistoryRetention      int    // 历史数据保存时间 h，0 表示永久保存
+	HistoryInterval       int    // 关键指标记录周期 s
+	HistoryFieldInterval  int    // 温度场记录周期 s
 }
 
 func Init() {
@@ -31,6 +36,7 @@ func Init() {
 func loadCfg(file *ini.File) {
 	AppConfig = &Config{
 		Port:                  file.Section("app").Key("Port").MustString(":9000"),
+		GrpcPort:              file.Section("app").Key("GrpcPort").MustString(""),
 		ReadBufferSize:        file.Section("app").Key("ReadBufferSize").MustInt(1024),
 		WriteBufferSize:       file.Section("app").Key("WriteBufferSize").MustInt(1024),
 		Mode:                  file.Section("app").Key("Mode").MustString("debug"),
@@ -38,6 +44,10 @@ func loadCfg(file *ini.File) {
 		PhysicalParameterFile: file.Section("app").Key("PhysicalParameterFile").MustString(""),
 		NozzleConfigFile:      file.Section("app").Key("NozzleConfigFile").MustString(""),
 		CasterHomePath:        file.Section("app").Key("CasterHomePath").MustString(""),
+		HistoryPath:           file.Section("history").Key("Path").MustString(""),
+		HistoryRetention:      file.Section("history").Key("Retention").MustInt(720),
+		HistoryInterval:       file.Section("history").Key("Interval").MustInt(10),
+		HistoryFieldInterval:  file.Section("history").Key("FieldInterval").MustInt(300),
 	}
 
 	log.Info("配置参数：", AppConfig)
@@ -0,0 +1,212 @@
+package connector
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"lz/calculator"
+	"lz/model"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// Modbus 功能码
+const (
+	funcReadHoldingRegisters = 0x03
+	funcReadInputRegisters   = 0x04
+)
+
+// 单次请求的超时时间
+const modbusTimeout = 2 * time.Second
+
+var ErrModbusException = errors.New("Modbus 从站返回异常")
+
+// Modbus TCP 数据源，按采样周期读取寄存器并写入铸机
+type Modbus struct {
+	cfg     model.ModbusCfg
+	applier *applier
+
+	mu          sync.Mutex
+	conn        net.Conn
+	transaction uint16
+}
+
+func NewModbus(cfg model.ModbusCfg, cm *calculator.CastingMachine) *Modbus {
+	m := &Modbus{
+		cfg:     cfg,
+		applier: newApplier(cm, staleAfter(cfg.Interval, cfg.StaleTimeout)),
+	}
+	for _, register := range cfg.Registers {
+		m.applier.register(register.Signal, register.Unit)
+	}
+	return m
+}
+
+// 连接 Modbus 从站
+func (m *Modbus) Connect(ctx context.Context) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", m.cfg.Address)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.conn = conn
+	m.mu.Unlock()
+	log.WithField("address", m.cfg.Address).Info("已连接 Modbus 从站")
+	return nil
+}
+
+// 按采样周期读取寄存器直到 ctx 取消，连接断开时在下一个周期重新连接
+func (m *Modbus) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(m.cfg.Interval * float32(time.Second)))
+	defer ticker.Stop()
+	for {
+		if err := m.poll(ctx); err != nil {
+			log.WithField("err", err).Error("读取 Modbus 寄存器失败")
+			m.applier.check()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// 断开连接
+func (m *Modbus) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.conn == nil {
+		return nil
+	}
+	log.WithField("address", m.cfg.Address).Info("断开 Modbus 从站")
+	err := m.conn.Close()
+	m.conn = nil
+	return err
+}
+
+// 各个信号的最新值和是否失效
+func (m *Modbus) Status() []SignalStatus {
+	return m.applier.status()
+}
+
+// 读取一次全部寄存器，单个寄存器读取异常时跳过，连接出错时断开等待重连
+func (m *Modbus) poll(ctx context.Context) error {
+	m.mu.Lock()
+	connected := m.conn != nil
+	m.mu.Unlock()
+	if !connected {
+		if err := m.Connect(ctx); err != nil {
+			return err
+		}
+	}
+	values := make(map[string]float32, len(m.cfg.Registers))
+	for _, register := range m.cfg.Registers {
+		value, err := m.read(register)
+		if errors.Is(err, ErrModbusException) {
+			log.WithFields(log.Fields{"signal": register.Signal, "err": err}).Warn("Modbus 寄存器读取失败")
+			continue
+		}
+		if err != nil {
+			m.Close()
+			return err
+		}
+		values[register.Signal] = value
+	}
+	m.applier.apply(values)
+	return nil
+}
+
+// 读取一个寄存器映射并换算为信号值
+func (m *Modbus) read(register model.ModbusRegister) (float32, error) {
+	function := byte(funcReadHoldingRegisters)
+	if register.Type == model.ModbusInput {
+		function = funcReadInputRegisters
+	}
+	var quantity uint16 = 1
+	switch register.DataType {
+	case model.ModbusUint32, model.ModbusInt32, model.ModbusFloat32:
+		quantity = 2
+	}
+	words, err := m.readRegisters(function, register.Address, quantity)
+	if err != nil {
+		return 0, err
+	}
+	var raw float64
+	switch register.DataType {
+	case model.ModbusInt16:
+		raw = float64(int16(words[0]))
+	case model.ModbusUint32:
+		raw = float64(uint32(words[0])<<16 | uint32(words[1]))
+	case model.ModbusInt32:
+		raw = float64(int32(uint32(words[0])<<16 | uint32(words[1])))
+	case model.ModbusFloat32:
+		raw = float64(math.Float32frombits(uint32(words[0])<<16 | uint32(words[1])))
+	default:
+		raw = float64(words[0])
+	}
+	scale := register.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	return float32(raw)*scale + register.Offset, nil
+}
+
+// 发送一次读寄存器请求
+func (m *Modbus) readRegisters(function byte, address, quantity uint16) ([]uint16, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.conn == nil {
+		return nil, net.ErrClosed
+	}
+	m.transaction++
+	// MBAP 报文头：事务标识、协议标识、长度、单元标识，之后为 PDU：功能码、起始地址、寄存器数量
+	req := make([]byte, 12)
+	binary.BigEndian.PutUint16(req[0:], m.transaction)
+	binary.BigEndian.PutUint16(req[4:], 6)
+	req[6] = m.cfg.UnitID
+	req[7] = function
+	binary.BigEndian.PutUint16(req[8:], address)
+	binary.BigEndian.PutUint16(req[10:], quantity)
+	m.conn.SetDeadline(time.Now().Add(modbusTimeout))
+	if _, err := m.conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(m.conn, header); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint16(header[4:])
+	if length < 2 || length > 256 {
+		return nil, fmt.Errorf("Modbus 响应长度 %d 不合法", length)
+	}
+	pdu := make([]byte, length-1)
+	if _, err := io.ReadFull(m.conn, pdu); err != nil {
+		return nil, err
+	}
+	if binary.BigEndian.Uint16(header[0:]) != m.transaction {
+		return nil, fmt.Errorf("Modbus 响应事务标识 %d 与请求 %d 不一致", binary.BigEndian.Uint16(header[0:]), m.transaction)
+	}
+	// 异常响应也至少有功能码和异常码两个字节
+	if len(pdu) < 2 {
+		return nil, fmt.Errorf("Modbus 响应不合法: % x", pdu)
+	}
+	if pdu[0] == function|0x80 {
+		return nil, fmt.Errorf("%w: 功能码 %d 地址 %d 异常码 %d", ErrModbusException, function, address, pdu[1])
+	}
+	if pdu[0] != function || int(pdu[1]) != int(quantity)*2 || len(pdu) != 2+int(quantity)*2 {
+		return nil, fmt.Errorf("Modbus 响应不合法: % x", pdu)
+	}
+	words := make([]uint16, quantity)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint16(pdu[2+2*i:])
+	}
+	return words, nil
+}
@@ -0,0 +1,177 @@
+package connector
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"lz/calculator"
+	"lz/model"
+	"math"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 本地 Modbus TCP 从站模拟器，只支持读保持寄存器和输入寄存器
+type slaveSimulator struct {
+	l         net.Listener
+	mu        sync.Mutex
+	registers map[byte]map[uint16]uint16 // 功能码 -> 地址 -> 寄存器值
+	truncated bool                       // 返回缺少异常码的异常响应
+}
+
+func startSlaveSimulator(t *testing.T) *slaveSimulator {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &slaveSimulator{
+		l: l,
+		registers: map[byte]map[uint16]uint16{
+			funcReadHoldingRegisters: {},
+			funcReadInputRegisters:   {},
+		},
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { l.Close() })
+	return s
+}
+
+func (s *slaveSimulator) set(function byte, address uint16, words ...uint16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, word := range words {
+		s.registers[function][address+uint16(i)] = word
+	}
+}
+
+func (s *slaveSimulator) remove(function byte, address uint16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.registers[function], address)
+}
+
+func (s *slaveSimulator) serve(conn net.Conn) {
+	defer conn.Close()
+	req := make([]byte, 12)
+	for {
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		function := req[7]
+		address := binary.BigEndian.Uint16(req[8:])
+		quantity := binary.BigEndian.Uint16(req[10:])
+		pdu := []byte{function, byte(2 * quantity)}
+		s.mu.Lock()
+		if s.truncated {
+			quantity = 0
+			pdu = []byte{function | 0x80}
+		}
+		for i := uint16(0); i < quantity; i++ {
+			word, ok := s.registers[function][address+i]
+			if !ok {
+				pdu = []byte{function | 0x80, 0x02} // 非法数据地址
+				break
+			}
+			pdu = binary.BigEndian.AppendUint16(pdu, word)
+		}
+		s.mu.Unlock()
+		resp := make([]byte, 7, 7+len(pdu))
+		copy(resp, req[:4])
+		binary.BigEndian.PutUint16(resp[4:], uint16(len(pdu)+1))
+		resp[6] = req[6]
+		if _, err := conn.Write(append(resp, pdu...)); err != nil {
+			return
+		}
+	}
+}
+
+func TestModbus(t *testing.T) {
+	sim := startSlaveSimulator(t)
+	speed := math.Float32bits(1.2)
+	sim.set(funcReadHoldingRegisters, 100, uint16(speed>>16), uint16(speed))
+	sim.set(funcReadHoldingRegisters, 102, 15420)       // 1542.0℃，系数 0.1
+	sim.set(funcReadInputRegisters, 10, uint16(0xfffe)) // 液面偏差 -2mm，设定值 100
+	sim.set(funcReadInputRegisters, 11, 750)            // 二冷 1 区水量
+	sim.set(funcReadInputRegisters, 12, 9850)           // 测温仪 985.0℃
+
+	cm := calculator.NewCastingMachine()
+	cm.Coordinate.MdLength = 950
+	cm.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{{InnerArcWaterVolume: 80}}
+	cm.SetPyrometers(model.PyrometerCfg{Points: []model.Pyrometer{{ID: "p1", Distance: 5000, Face: model.FaceInner}}})
+	m := NewModbus(model.ModbusCfg{
+		Address:      sim.l.Addr().String(),
+		Interval:     1,
+		StaleTimeout: 5,
+		Registers: []model.ModbusRegister{
+			{Signal: model.SignalSpeed, Address: 100, DataType: model.ModbusFloat32, Unit: "m/min"},
+			{Signal: model.SignalTundishTemperature, Address: 102, Scale: 0.1, Unit: "℃"},
+			{Signal: model.SignalMoldLevel, Address: 10, Type: model.ModbusInput, DataType: model.ModbusInt16, Offset: 100, Unit: "mm"},
+			{Signal: "zone_1", Address: 11, Type: model.ModbusInput, Scale: 0.1, Unit: "L/min"},
+			{Signal: "pyrometer_p1", Address: 12, Type: model.ModbusInput, Scale: 0.1, Unit: "℃"},
+		},
+	}, cm)
+	now := time.Now()
+	m.applier.now = func() time.Time { return now }
+	ctx := context.Background()
+	if err := m.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if err := m.poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if cm.CoolerConfig.V != 20 {
+		t.Fatal("拉速应为 20 mm/s, 实际为", cm.CoolerConfig.V)
+	}
+	if cm.CoolerConfig.StartTemperature != 1542 {
+		t.Fatal("浇铸温度不正确:", cm.CoolerConfig.StartTemperature)
+	}
+	if s := cm.GetMeniscusStability(); s.Level != 98 {
+		t.Fatalf("液面测量值不正确: %+v", s)
+	}
+	if p := cm.GetPyrometerReport().Points[0]; p.Measured != 985 || p.Updated.IsZero() {
+		t.Fatalf("测温仪测量值不正确: %+v", p)
+	}
+	for _, status := range m.Status() {
+		if status.Stale || !status.Updated.Equal(now) {
+			t.Fatalf("信号状态不正确: %+v", status)
+		}
+	}
+
+	// 中间包温度寄存器不可读，超过失效时间后该信号失效，其余信号正常
+	sim.remove(funcReadHoldingRegisters, 102)
+	now = now.Add(6 * time.Second)
+	if err := m.poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range m.Status() {
+		if status.Stale != (status.Signal == model.SignalTundishTemperature) {
+			t.Fatalf("信号状态不正确: %+v", status)
+		}
+	}
+
+	// 从站断开后重新连接
+	m.Close()
+	if err := m.poll(ctx); err != nil {
+		t.Fatal("断开后应重新连接:", err)
+	}
+
+	// 截断的异常响应返回错误并断开连接
+	sim.mu.Lock()
+	sim.truncated = true
+	sim.mu.Unlock()
+	if err := m.poll(ctx); err == nil || errors.Is(err, ErrModbusException) {
+		t.Fatal("截断的响应应返回响应不合法:", err)
+	}
+}
@@ -0,0 +1,124 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	log "github.com/sirupsen/logrus"
+	"lz/calculator"
+	"lz/model"
+	"time"
+)
+
+// OPC UA 数据源，按采样周期读取配置的节点并写入铸机
+type OpcUa struct {
+	cfg     model.OpcUaCfg
+	signals []string // 信号名称，与 req 中的节点一一对应
+	req     *ua.ReadRequest
+	client  *opcua.Client
+	applier *applier
+}
+
+// 解析节点 ID，节点 ID 不合法时返回错误
+func NewOpcUa(cfg model.OpcUaCfg, cm *calculator.CastingMachine) (*OpcUa, error) {
+	o := &OpcUa{
+		cfg:     cfg,
+		req:     &ua.ReadRequest{TimestampsToReturn: ua.TimestampsToReturnNeither},
+		applier: newApplier(cm, staleAfter(cfg.Interval, 0)),
+	}
+	for signal, node := range cfg.Nodes {
+		id, err := ua.ParseNodeID(node)
+		if err != nil {
+			return nil, fmt.Errorf("信号 %s 的节点 %s 不合法: %w", signal, node, err)
+		}
+		o.signals = append(o.signals, signal)
+		o.applier.register(signal, "")
+		o.req.NodesToRead = append(o.req.NodesToRead, &ua.ReadValueID{NodeID: id, AttributeID: ua.AttributeIDValue})
+	}
+	return o, nil
+}
+
+// 连接 OPC UA 服务器，不使用加密
+func (o *OpcUa) Connect(ctx context.Context) error {
+	client, err := opcua.NewClient(o.cfg.Endpoint, opcua.SecurityMode(ua.MessageSecurityModeNone))
+	if err != nil {
+		return err
+	}
+	if err = client.Connect(ctx); err != nil {
+		return err
+	}
+	o.client = client
+	log.WithField("endpoint", o.cfg.Endpoint).Info("已连接 OPC UA 服务器")
+	return nil
+}
+
+// 按采样周期读取节点直到 ctx 取消，读取失败时等待下一个周期重试
+func (o *OpcUa) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(o.cfg.Interval * float32(time.Second)))
+	defer ticker.Stop()
+	for {
+		if err := o.poll(ctx); err != nil {
+			log.WithField("err", err).Error("读取 OPC UA 节点失败")
+			o.applier.check()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// 断开连接
+func (o *OpcUa) Close() error {
+	if o.client == nil {
+		return nil
+	}
+	log.WithField("endpoint", o.cfg.Endpoint).Info("断开 OPC UA 服务器")
+	return o.client.Close(context.Background())
+}
+
+// 各个信号的最新值和是否失效
+func (o *OpcUa) Status() []SignalStatus {
+	return o.applier.status()
+}
+
+// 读取一次全部节点
+func (o *OpcUa) poll(ctx context.Context) error {
+	resp, err := o.client.Read(ctx, o.req)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]float32, len(o.signals))
+	for i, result := range resp.Results {
+		if i >= len(o.signals) {
+			break
+		}
+		if result.Status != ua.StatusOK || result.Value == nil {
+			log.WithFields(log.Fields{"signal": o.signals[i], "status": result.Status}).Warn("OPC UA 节点读取失败")
+			continue
+		}
+		value, ok := variantFloat(result.Value)
+		if !ok {
+			log.WithFields(log.Fields{"signal": o.signals[i], "type": result.Value.Type()}).Warn("OPC UA 节点不是数值类型")
+			continue
+		}
+		values[o.signals[i]] = value
+	}
+	o.applier.apply(values)
+	return nil
+}
+
+// 把整数和浮点数类型的节点值转换为 float32
+func variantFloat(v *ua.Variant) (float32, bool) {
+	switch v.Type() {
+	case ua.TypeIDFloat, ua.TypeIDDouble:
+		return float32(v.Float()), true
+	case ua.TypeIDSByte, ua.TypeIDInt16, ua.TypeIDInt32, ua.TypeIDInt64:
+		return float32(v.Int()), true
+	case ua.TypeIDByte, ua.TypeIDUint16, ua.TypeIDUint32, ua.TypeIDUint64:
+		return float32(v.Uint()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,122 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/server"
+	"github.com/gopcua/opcua/ua"
+	"lz/calculator"
+	"lz/model"
+	"net"
+	"testing"
+	"time"
+)
+
+// 启动本地 OPC UA 模拟服务器，返回地址和节点所在的命名空间
+func startSimulator(t *testing.T, values map[string]interface{}) (string, uint16) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	s := server.New(
+		server.EnableSecurity("None", ua.MessageSecurityModeNone),
+		server.EnableAuthMode(ua.UserTokenTypeAnonymous),
+		server.EndPoint("localhost", port),
+	)
+	root, _ := s.Namespace(0)
+	ns := server.NewNodeNameSpace(s, "Caster")
+	s.AddNamespace(ns)
+	root.Objects().AddRef(ns.Objects(), id.HasComponent, true)
+	for name, value := range values {
+		ns.Objects().AddRef(ns.AddNewVariableStringNode(name, value), id.HasComponent, true)
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return fmt.Sprintf("opc.tcp://localhost:%d", port), ns.ID()
+}
+
+func TestOpcUa(t *testing.T) {
+	endpoint, ns := startSimulator(t, map[string]interface{}{
+		"Caster.Speed":      float64(1.8),
+		"Caster.TundishT":   float32(1545),
+		"Caster.MoldLevel":  int32(105),
+		"Caster.Zone2Water": float64(60),
+		"Caster.Status":     "running",
+	})
+	cm := calculator.NewCastingMachine()
+	cm.Coordinate.MdLength = 950
+	cm.CoolerConfig.WideSurfaceIn, cm.CoolerConfig.WideSurfaceOut = 30, 38
+	cm.CoolerConfig.SecondaryCoolingZoneCfg.SecondaryCoolingWaterCfg = []model.SecondaryCoolingWaterSection{
+		{InnerArcWaterVolume: 100},
+		{InnerArcWaterVolume: 80, NarrowSideWaterVolume: 40},
+	}
+	node := func(name string) string { return fmt.Sprintf("ns=%d;s=%s", ns, name) }
+	o, err := NewOpcUa(model.OpcUaCfg{
+		Endpoint: endpoint,
+		Interval: 0.1,
+		Nodes: map[string]string{
+			model.SignalSpeed:              node("Caster.Speed"),
+			model.SignalTundishTemperature: node("Caster.TundishT"),
+			model.SignalMoldLevel:          node("Caster.MoldLevel"),
+			"zone_2":                       node("Caster.Zone2Water"),
+			model.SignalWideSurfaceIn:      node("Caster.Status"),
+			model.SignalWideSurfaceOut:     node("Caster.Missing"),
+		},
+	}, cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := o.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	now := time.Now()
+	o.applier.now = func() time.Time { return now }
+	if err := o.poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if cm.CoolerConfig.V != 30 {
+		t.Fatal("拉速应为 30 mm/s, 实际为", cm.CoolerConfig.V)
+	}
+	if cm.CoolerConfig.StartTemperature != 1545 {
+		t.Fatal("浇铸温度不正确:", cm.CoolerConfig.StartTemperature)
+	}
+	if s := cm.GetMeniscusStability(); s.Samples != 1 || s.Level != 105 {
+		t.Fatalf("液面测量值不正确: %+v", s)
+	}
+	if v, err := cm.GetZoneWaterVolume(2); err != nil || v != 60 {
+		t.Fatal("二冷 2 区内弧水量应为 60:", v, err)
+	}
+	// 字符串节点和不存在的节点不修改原有的数值
+	if cm.CoolerConfig.WideSurfaceIn != 30 || cm.CoolerConfig.WideSurfaceOut != 38 {
+		t.Fatal("非数值节点不应修改宽面水温:", cm.CoolerConfig.WideSurfaceIn, cm.CoolerConfig.WideSurfaceOut)
+	}
+
+	// 超过失效时间后再次读取，读不到数值的信号失效，其余信号正常
+	now = now.Add(time.Second)
+	if err := o.poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range o.Status() {
+		bad := status.Signal == model.SignalWideSurfaceIn || status.Signal == model.SignalWideSurfaceOut
+		if status.Stale != bad || status.Updated.IsZero() == !bad {
+			t.Fatalf("信号状态不正确: %+v", status)
+		}
+	}
+}
+
+func TestNewOpcUaInvalidNode(t *testing.T) {
+	_, err := NewOpcUa(model.OpcUaCfg{Nodes: map[string]string{model.SignalSpeed: "ns=x;s=Speed"}}, calculator.NewCastingMachine())
+	if err == nil {
+		t.Fatal("不合法的节点 ID 应该被拒绝")
+	}
+}
@@ -0,0 +1,180 @@
+package connector
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"lz/calculator"
+	"lz/model"
+	"lz/validation"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 没有配置失效时间时，超过 3 个采样周期没有读到新值认为信号失效
+const DefaultStaleIntervals = 3
+
+// 现场数据源
+type Source interface {
+	// 连接数据源
+	Connect(ctx context.Context) error
+	// 按采样周期读取数据并写入铸机，直到 ctx 取消
+	Run(ctx context.Context)
+	Close() error
+	// 各个信号的最新值和是否失效
+	Status() []SignalStatus
+}
+
+// 信号状态
+type SignalStatus struct {
+	Signal  string    `json:"signal"`
+	Value   float32   `json:"value"`
+	Unit    string    `json:"unit,omitempty"`
+	Updated time.Time `json:"updated"` // 最近一次读到数值的时间，从未读到时为零值
+	Stale   bool      `json:"stale"`
+}
+
+// 把现场信号写入铸机并记录信号状态，各种数据源共用
+type applier struct {
+	cm         *calculator.CastingMachine
+	staleAfter time.Duration
+	now        func() time.Time
+	started    time.Time // 从未读到数值的信号从该时间开始计算失效时间
+
+	mu      sync.Mutex
+	last    map[string]float32 // 上次写入的数值
+	signals map[string]*SignalStatus
+}
+
+func newApplier(cm *calculator.CastingMachine, staleAfter time.Duration) *applier {
+	return &applier{
+		cm:         cm,
+		staleAfter: staleAfter,
+		now:        time.Now,
+		started:    time.Now(),
+		last:       make(map[string]float32),
+		signals:    make(map[string]*SignalStatus),
+	}
+}
+
+// 登记需要跟踪状态的信号
+func (a *applier) register(signal, unit string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.signals[signal] = &SignalStatus{Signal: signal, Unit: unit}
+}
+
+// 写入一次采样得到的全部信号，数值不合法的信号跳过
+// 除结晶器液面和测温仪外只有数值变化时才调用对应的设置方法，液面的每个测量值都用于计算液面波动，
+// 测温仪的每个测量值都与计算表面温度比较
+func (a *applier) apply(values map[string]float32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	for name, value := range values {
+		if status, ok := a.signals[name]; ok {
+			if status.Stale {
+				log.WithField("signal", name).Info("现场信号恢复")
+			}
+			status.Value, status.Updated, status.Stale = value, now, false
+		}
+		if last, ok := a.last[name]; ok && last == value && !everySample(name) {
+			continue
+		}
+		if errs := validation.Signal(name, name, value, a.cm.Coordinate.MdLength); len(errs) > 0 {
+			log.WithField("err", errs).Warn("现场信号数值不合法")
+			continue
+		}
+		if err := a.set(name, value); err != nil {
+			log.WithFields(log.Fields{"signal": name, "err": err}).Warn("写入现场信号失败")
+			continue
+		}
+		a.last[name] = value
+	}
+	a.checkStale(now)
+}
+
+// 读取失败时检查信号是否失效
+func (a *applier) check() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.checkStale(a.now())
+}
+
+// 超过失效时间没有读到新值的信号标记为失效，需持有 a.mu
+func (a *applier) checkStale(now time.Time) {
+	for name, status := range a.signals {
+		updated := status.Updated
+		if updated.IsZero() {
+			updated = a.started
+		}
+		if !status.Stale && now.Sub(updated) > a.staleAfter {
+			status.Stale = true
+			log.WithFields(log.Fields{"signal": name, "updated": status.Updated}).Warn("现场信号失效")
+		}
+	}
+}
+
+// 按信号名称排序的信号状态
+func (a *applier) status() []SignalStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.checkStale(a.now())
+	res := make([]SignalStatus, 0, len(a.signals))
+	for _, status := range a.signals {
+		res = append(res, *status)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Signal < res[j].Signal
+	})
+	return res
+}
+
+func (a *applier) set(name string, value float32) error {
+	cm := a.cm
+	switch name {
+	case model.SignalSpeed:
+		// 现场拉速优先于拉速曲线
+		cm.StopSpeedSchedule()
+		cm.SetV(value)
+	case model.SignalNarrowSurfaceIn:
+		cm.SetNarrowSurfaceIn(value)
+	case model.SignalNarrowSurfaceOut:
+		cm.SetNarrowSurfaceOut(value)
+	case model.SignalNarrowSurfaceVolume:
+		cm.SetNarrowWaterVolume(value)
+	case model.SignalWideSurfaceIn:
+		cm.SetWideSurfaceIn(value)
+	case model.SignalWideSurfaceOut:
+		cm.SetWideSurfaceOut(value)
+	case model.SignalWideSurfaceVolume:
+		cm.SetWideWaterVolume(value)
+	case model.SignalTundishTemperature:
+		// 现场测温优先于中间包温度模型
+		cm.StopTundish()
+		cm.SetStartTemperature(value)
+	case model.SignalMoldLevel:
+		cm.AddMoldLevel(value)
+	default:
+		if id, ok := model.PyrometerSignal(name); ok {
+			return cm.AddPyrometerReading(id, value)
+		}
+		zone, _ := model.ZoneSignal(name)
+		return cm.SetZoneWaterVolume(zone, value)
+	}
+	return nil
+}
+
+// 每个采样值都需要写入的信号
+func everySample(name string) bool {
+	_, ok := model.PyrometerSignal(name)
+	return ok || name == model.SignalMoldLevel
+}
+
+// 没有配置失效时间时按采样周期计算
+func staleAfter(interval, staleTimeout float32) time.Duration {
+	if staleTimeout > 0 {
+		return time.Duration(staleTimeout * float32(time.Second))
+	}
+	return DefaultStaleIntervals * time.Duration(interval*float32(time.Second))
+}
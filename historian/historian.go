@@ -0,0 +1,235 @@
+package historian
+
+import (
+	"bufio"
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"lz/model"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ext           = ".jsonl"
+	segmentLayout = "2006010215" // 每小时一个数据文件，按 UTC 时间命名
+	segmentSpan   = time.Hour
+	maxLineSize   = 16 << 20
+
+	MaxQueryRecords = 1000     // 单次查询最多返回的记录数
+	MaxQueryBytes   = 16 << 20 // 单次查询最多返回的记录数据字节数，温度场记录较大时先达到这个限制
+
+	// 温度场降采样：每隔 25 个切片（250mm）取一个切片，切片内每隔 5 个点（25mm）取一个点
+	FieldZStride  = 25
+	FieldXYStride = 5
+)
+
+// 一条历史记录
+type Record struct {
+	Time   time.Time       `json:"time"`
+	Kind   string          `json:"kind"`
+	Caster string          `json:"caster"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// 查询结果，超过 MaxQueryRecords 或 MaxQueryBytes 时只返回最早的记录，
+// Next 为下一条未返回记录的时间，Skip 为时间等于 Next 的记录中已经返回的条数，
+// 以它们作为 Start 和 Skip 再次查询得到后面的记录，同一时间的记录再多也能向后推进
+type QueryResult struct {
+	Records   []Record   `json:"records"`
+	Truncated bool       `json:"truncated"`
+	Next      *time.Time `json:"next,omitempty"`
+	Skip      int        `json:"skip,omitempty"`
+
+	size    int // 已返回记录的数据字节数
+	skipped int // 已跳过的时间等于 Start 的记录条数
+}
+
+// 嵌入式历史数据库，记录按时间追加到 dir 下的数据文件，每行一条 json 记录
+type Historian struct {
+	dir       string
+	retention time.Duration // 0 表示永久保存
+	now       func() time.Time
+
+	mu      sync.Mutex
+	file    *os.File
+	segment string // 当前写入的数据文件名
+}
+
+// 打开历史数据目录，不存在时创建，并删除超过保存期限的数据
+func NewHistorian(dir string, retention time.Duration) (*Historian, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	h := &Historian{dir: dir, retention: retention, now: time.Now}
+	if err := h.prune(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// 追加一条记录
+func (h *Historian) Write(kind, caster string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	line, err := json.Marshal(Record{Time: now, Kind: kind, Caster: caster, Data: data})
+	if err != nil {
+		return err
+	}
+	segment := now.UTC().Format(segmentLayout) + ext
+	if segment != h.segment {
+		// 进入新的小时，切换数据文件并清理过期数据
+		h.closeFile()
+		h.file, err = os.OpenFile(filepath.Join(h.dir, segment), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		h.segment = segment
+		if err = h.prune(); err != nil {
+			log.WithField("err", err).Warn("清理过期历史数据失败")
+		}
+	}
+	_, err = h.file.Write(append(line, '\n'))
+	return err
+}
+
+// 按时间范围查询记录，按时间升序
+func (h *Historian) Query(q model.HistoryQuery) (*QueryResult, error) {
+	segments, err := h.segments()
+	if err != nil {
+		return nil, err
+	}
+	res := &QueryResult{Records: make([]Record, 0)}
+	for _, s := range segments {
+		if !s.start.Before(q.End) || !s.start.Add(segmentSpan).After(q.Start) {
+			continue
+		}
+		if err = h.scan(s.name, q, res); err != nil {
+			return nil, err
+		}
+		if res.Truncated {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (h *Historian) scan(name string, q model.HistoryQuery, res *QueryResult) error {
+	f, err := os.Open(filepath.Join(h.dir, name))
+	if os.IsNotExist(err) {
+		// 查询期间被清理
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var r Record
+		if err = json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			// 写入一半时程序退出留下的不完整记录
+			log.WithFields(log.Fields{"file": name, "err": err}).Warn("跳过损坏的历史记录")
+			continue
+		}
+		if r.Kind != q.Kind || (q.Caster != "" && r.Caster != q.Caster) || r.Time.Before(q.Start) || !r.Time.Before(q.End) {
+			continue
+		}
+		if r.Time.Equal(q.Start) && res.skipped < q.Skip {
+			res.skipped++
+			continue
+		}
+		// 至少返回一条记录，保证分页查询时能向后推进
+		if len(res.Records) == MaxQueryRecords || (len(res.Records) > 0 && res.size+len(r.Data) > MaxQueryBytes) {
+			res.Truncated = true
+			res.Next = &r.Time
+			// 记录按时间升序，时间等于 Next 的已返回记录都在末尾
+			for i := len(res.Records) - 1; i >= 0 && res.Records[i].Time.Equal(r.Time); i-- {
+				res.Skip++
+			}
+			if r.Time.Equal(q.Start) {
+				res.Skip += res.skipped
+			}
+			return nil
+		}
+		res.Records = append(res.Records, r)
+		res.size += len(r.Data)
+	}
+	return scanner.Err()
+}
+
+// 关闭当前数据文件
+func (h *Historian) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.closeFile()
+}
+
+func (h *Historian) closeFile() error {
+	if h.file == nil {
+		return nil
+	}
+	err := h.file.Close()
+	h.file, h.segment = nil, ""
+	return err
+}
+
+// 删除整个小时都超过保存期限的数据文件
+func (h *Historian) prune() error {
+	if h.retention <= 0 {
+		return nil
+	}
+	segments, err := h.segments()
+	if err != nil {
+		return err
+	}
+	deadline := h.now().Add(-h.retention)
+	for _, s := range segments {
+		if s.name == h.segment || s.start.Add(segmentSpan).After(deadline) {
+			continue
+		}
+		if err = os.Remove(filepath.Join(h.dir, s.name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		log.WithField("file", s.name).Info("删除过期历史数据")
+	}
+	return nil
+}
+
+type segment struct {
+	name  string
+	start time.Time
+}
+
+// 按时间升序的数据文件，忽略名称不符合格式的文件
+func (h *Historian) segments() ([]segment, error) {
+	files, err := ioutil.ReadDir(h.dir)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]segment, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ext {
+			continue
+		}
+		start, err := time.Parse(segmentLayout, strings.TrimSuffix(f.Name(), ext))
+		if err != nil {
+			continue
+		}
+		res = append(res, segment{name: f.Name(), start: start})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].start.Before(res[j].start)
+	})
+	return res, nil
+}
@@ -0,0 +1,163 @@
+package historian
+
+import (
+	"encoding/json"
+	"lz/model"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistorian(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewHistorian(dir, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	start := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	now := start
+	h.now = func() time.Time { return now }
+	// 每 30 分钟记录一次，共 4 小时
+	for i := 0; i < 8; i++ {
+		now = start.Add(time.Duration(i) * 30 * time.Minute)
+		if err = h.Write(model.HistoryIndicators, "caster-1", map[string]float32{"crater_end": float32(i)}); err != nil {
+			t.Fatal(err)
+		}
+		if err = h.Write(model.HistoryField, "caster-1", []int{i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 8 点的数据已全部超过保存期限，9 点的数据还有一部分未过期
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+ext))
+	if len(files) != 3 {
+		t.Fatal("应只保留 3 个数据文件:", files)
+	}
+
+	// 模拟写入一半的记录
+	f, _ := os.OpenFile(filepath.Join(dir, "2026101911"+ext), os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString(`{"time":"2026-10-19T11:45:00Z","kind":"indi`)
+	f.Close()
+
+	res, err := h.Query(model.HistoryQuery{
+		Kind:  model.HistoryIndicators,
+		Start: start.Add(150 * time.Minute),
+		End:   start.Add(5 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != 3 || res.Truncated {
+		t.Fatal("查询结果数量不正确:", len(res.Records))
+	}
+	var data map[string]float32
+	if err = json.Unmarshal(res.Records[0].Data, &data); err != nil || data["crater_end"] != 5 {
+		t.Fatal("查询结果不正确:", string(res.Records[0].Data))
+	}
+
+	res, err = h.Query(model.HistoryQuery{Kind: model.HistoryField, Caster: "caster-2", Start: start, End: now.Add(time.Hour)})
+	if err != nil || len(res.Records) != 0 {
+		t.Fatal("不应查询到其他铸机的数据:", res, err)
+	}
+}
+
+func TestHistorianTruncated(t *testing.T) {
+	h, err := NewHistorian(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	start := time.Now()
+	for i := 0; i < MaxQueryRecords+1; i++ {
+		if err = h.Write(model.HistoryIndicators, "", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := h.Query(model.HistoryQuery{Kind: model.HistoryIndicators, Start: start.Add(-time.Second), End: time.Now().Add(time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != MaxQueryRecords || !res.Truncated {
+		t.Fatal("应只返回前", MaxQueryRecords, "条记录:", len(res.Records))
+	}
+}
+
+func TestHistorianSameTime(t *testing.T) {
+	h, err := NewHistorian(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	now := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	total := MaxQueryRecords*2 + 10
+	for i := 0; i < total; i++ {
+		if err = h.Write(model.HistoryIndicators, "", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 所有记录时间相同，按 Next 和 Skip 分页仍能不重复地取完
+	q := model.HistoryQuery{Kind: model.HistoryIndicators, Start: now, End: now.Add(time.Second)}
+	var got []int
+	for page := 0; page < 4; page++ {
+		res, err := h.Query(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range res.Records {
+			var i int
+			if err = json.Unmarshal(r.Data, &i); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, i)
+		}
+		if !res.Truncated {
+			break
+		}
+		q.Start, q.Skip = *res.Next, res.Skip
+	}
+	if len(got) != total {
+		t.Fatal("分页查询记录数不正确:", len(got))
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatal("分页查询记录重复或缺失:", i, v)
+		}
+	}
+}
+
+func TestHistorianByteBudget(t *testing.T) {
+	h, err := NewHistorian(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	start := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	now := start
+	h.now = func() time.Time { return now }
+	// 每条温度场记录约 1MB，共 20 条
+	field := strings.Repeat("0", 1<<20)
+	for i := 0; i < 20; i++ {
+		now = start.Add(time.Duration(i) * time.Minute)
+		if err = h.Write(model.HistoryField, "", field); err != nil {
+			t.Fatal(err)
+		}
+	}
+	q := model.HistoryQuery{Kind: model.HistoryField, Start: start, End: now.Add(time.Minute)}
+	res, err := h.Query(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != 15 || !res.Truncated || res.Next == nil || !res.Next.Equal(start.Add(15*time.Minute)) {
+		t.Fatal("应按字节数限制截断:", len(res.Records), res.Next)
+	}
+	q.Start = *res.Next
+	if res, err = h.Query(q); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != 5 || res.Truncated || res.Next != nil {
+		t.Fatal("分页查询结果不正确:", len(res.Records), res.Next)
+	}
+}
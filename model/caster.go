@@ -0,0 +1,84 @@
+package model
+
+// 铸机定义，对应 CasterHomePath 下的一个 json 文件
+type Caster struct {
+	Name                 string              `json:"name"`
+	Version              int                 `json:"version"`
+	UpdatedAt            string              `json:"updated_at"`
+	Coordinate           Coordinate          `json:"coordinate"`
+	Md                   CasterMd            `json:"md"`
+	CoolingZone          []CasterCoolingZone `json:"cooling_zone"`
+	Segments             []Segment           `json:"segments"`
+	Arc                  Arc                 `json:"arc"`
+	SecondaryCoolingZone []Roller            `json:"secondary_cooling_zone"`
+	NozzleCfg            *NozzleCfg          `json:"nozzle_cfg,omitempty"`  // 喷嘴布置
+	NozzleFile           string              `json:"nozzle_file,omitempty"` // 喷嘴布置文件，相对铸机目录，NozzleCfg 为空时使用
+	Ems                  []Ems               `json:"ems,omitempty"`         // 电磁搅拌
+}
+
+// 结晶器尺寸
+type CasterMd struct {
+	Length float32 `json:"length"`
+}
+
+// 铸机冷却区定义，包含默认水量
+type CasterCoolingZone struct {
+	ZoneName              string  `json:"zone_name"`
+	Start                 int     `json:"start"`
+	End                   int     `json:"end"`
+	Medium                int     `json:"medium"`
+	InnerArcVolume        float32 `json:"inner_arc_volume"`
+	NarrowSideVolume      float32 `json:"narrow_side_volume"`
+	SprayWaterTemperature float32 `json:"spray_water_temperature"`
+	Fuqie1Volume          float32 `json:"fuqie_1_volume"`
+	Fuqie2Volume          float32 `json:"fuqie_2_volume"`
+}
+
+// 扇形段，Start、End 为辊子编号
+type Segment struct {
+	Name           string  `json:"seg"`
+	Start          int     `json:"start"`
+	End            int     `json:"end"`
+	EntryThickness float32 `json:"entry_thickness"` // 入口名义辊缝，即入口铸坯厚度
+	ExitThickness  float32 `json:"exit_thickness"`  // 出口名义辊缝，即出口铸坯厚度
+}
+
+// 辊子对，内外弧两个辊子
+type RollPair struct {
+	RollerNum int     `json:"roller_num"`
+	Distance  float32 `json:"distance"` // 距弯月面的距离
+	Gap       float32 `json:"gap"`      // 名义辊缝
+}
+
+// 弧形段起止辊子编号
+type Arc struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// 辊子布置
+type Roller struct {
+	RollerNum     int     `json:"roller_num"`
+	OuterDiameter float32 `json:"outer_diameter"`
+	OuterX        float32 `json:"outer_x"`
+	OuterY        float32 `json:"outer_y"`
+	InnerDiameter float32 `json:"inner_diameter"`
+	InnerX        float32 `json:"inner_x"`
+	InnerY        float32 `json:"inner_y"`
+	Distance      float32 `json:"distance"`
+	Angle         float32 `json:"angle"`
+}
+
+// 电磁搅拌器
+type Ems struct {
+	Name          string  `json:"name"`
+	StartDistance float32 `json:"start_distance"`
+	EndDistance   float32 `json:"end_distance"`
+	Factor        float32 `json:"factor"` // 对换热修正系数的影响因子
+}
+
+// 铸机历史版本
+type CasterVersion struct {
+	Version   int    `json:"version"`
+	UpdatedAt string `json:"updated_at"`
+}
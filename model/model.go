@@ -1,6 +1,9 @@
 package model
 
+import "time"
+
 type Env struct {
+	Caster                   string                         `json:"caster"` // 铸机名称，为空时使用 select_caster 选择的铸机
 	LevelHeight              float32                        `json:"level_height"`
 	SteelValue               int                            `json:"steel_value"`
 	StartTemperature         float32                        `json:"start_temperature"`
@@ -26,6 +29,17 @@ type Coordinate struct {
 	ZScale              int     `json:"z_scale"`
 	XScale              int     `json:"x_scale"`
 	YScale              int     `json:"y_scale"`
+
+	// 弯曲、矫直区，为空时按 [CenterStartDistance, CenterEndDistance] 半径为 R 的单段圆弧处理
+	BendingZones []BendingZone `json:"bending_zones,omitempty"`
+}
+
+// 弯曲或矫直区，区内曲率从 1/StartRadius 线性变化到 1/EndRadius，半径为 0 表示直线
+type BendingZone struct {
+	StartDistance float32 `json:"start_distance"`
+	EndDistance   float32 `json:"end_distance"`
+	StartRadius   float32 `json:"start_radius"`
+	EndRadius     float32 `json:"end_radius"`
 }
 
 // 冷却区分区配置
@@ -61,12 +75,38 @@ type WideSurface struct {
 	Volume float32 `json:"volume"`
 }
 
+// 水表适用的拉速范围 m/min，超出范围时取边界值，Step 大于 0 时拉速按 Step 取整后查表
 type Speed2Water struct {
 	Top    float32 `json:"top"`
 	Bottom float32 `json:"bottom"`
 	Step   float32 `json:"step"`
 }
 
+// 二冷控制模式
+const (
+	CoolingModeManual  = "manual"  // 手动设置水量
+	CoolingModeTable   = "table"   // 按拉速查水表
+	CoolingModeDynamic = "dynamic" // 按目标表面温度动态控制
+)
+
+// 水表，某钢种某冷却区内弧水量与拉速的关系
+// Points 不为空时按分段线性插值，否则按 Q = A*v^2 + B*v + C 计算
+// 窄面和幅切水量按与内弧水量的当前比例同步调整
+type WaterTable struct {
+	SteelValue int          `json:"steel_value"`
+	ZoneName   string       `json:"zone_name"`
+	Speed      Speed2Water  `json:"speed"`
+	A          float32      `json:"a"`
+	B          float32      `json:"b"`
+	C          float32      `json:"c"`
+	Points     []WaterPoint `json:"points"`
+}
+
+type WaterPoint struct {
+	Speed  float32 `json:"speed"`  // m/min
+	Volume float32 `json:"volume"` // L/min
+}
+
 // 纵切面云图请求结构体
 type VerticalReqData struct {
 	Index  int `json:"index"`
@@ -121,8 +161,7 @@ type CoolerCfg struct {
 	WideWaterVolume float32 // 宽面水量
 	// 结晶器冷却参数配置 ---- end
 
-	// todo 暂时未用到
-	TargetTemperature map[string]float32 // 每个冷却区的目标温度
+	TargetTemperature map[string]float32 // 每个冷却区的目标表面温度，由动态二冷控制按当前钢种设置
 
 	V int64 // 拉速
 
@@ -151,18 +190,20 @@ type NozzleCfg struct {
 }
 
 type WideItem struct {
-	RollerNum                     int     `json:"roller_num"`
-	CoolingZone                   int     `json:"cooling_zone"`
-	OuterDiameter                 int     `json:"outer_diameter"`
-	InnerDiameter                 int     `json:"inner_diameter"`
-	Medium                        int     `json:"medium"`
-	Distance                      float32 `json:"distance"`
-	RollerInnerDiameter           int     `json:"roller_inner_diameter"`
-	RollerDistance                float32 `json:"roller_distance"`
-	CenterSpraySection            Section `json:"center_spray_section"`
-	AlterSpraySection1            Section `json:"alter_spray_section_1"`
-	AlterSpraySection2            Section `json:"alter_spray_section_2"`
-	ElectromagneticStirringFactor float32 `json:"electromagnetic_stirring_factor"`
+	RollerNum                     int      `json:"roller_num"`
+	CoolingZone                   int      `json:"cooling_zone"`
+	OuterDiameter                 int      `json:"outer_diameter"`
+	InnerDiameter                 int      `json:"inner_diameter"`
+	Medium                        int      `json:"medium"`
+	Distance                      float32  `json:"distance"`
+	RollerInnerDiameter           int      `json:"roller_inner_diameter"`
+	RollerDistance                float32  `json:"roller_distance"`
+	CenterSpraySection            Section  `json:"center_spray_section"`
+	AlterSpraySection1            Section  `json:"alter_spray_section_1"`
+	AlterSpraySection2            Section  `json:"alter_spray_section_2"`
+	ElectromagneticStirringFactor float32  `json:"electromagnetic_stirring_factor"`
+	Disabled                      bool     `json:"disabled,omitempty"`   // 喷嘴堵死或关闭
+	Efficiency                    *float32 `json:"efficiency,omitempty"` // 喷嘴效率 [0, 1]，0 表示完全堵塞，未设置时按 1 处理
 }
 
 type NarrowItem struct {
@@ -173,6 +214,36 @@ type NarrowItem struct {
 	SpraySection1  NarrowSection `json:"spray_section_1"`
 	SpraySection2  NarrowSection `json:"spray_section_2"`
 	SpraySection3  NarrowSection `json:"spray_section_3"`
+	Disabled       bool          `json:"disabled,omitempty"`   // 喷嘴堵死或关闭
+	Efficiency     *float32      `json:"efficiency,omitempty"` // 喷嘴效率 [0, 1]，0 表示完全堵塞，未设置时按 1 处理
+}
+
+// 喷嘴的实际水量系数
+func (item WideItem) FlowFactor() float32 {
+	return flowFactor(item.Disabled, item.Efficiency)
+}
+
+// 喷嘴的实际水量系数
+func (item NarrowItem) FlowFactor() float32 {
+	return flowFactor(item.Disabled, item.Efficiency)
+}
+
+func flowFactor(disabled bool, efficiency *float32) float32 {
+	if disabled {
+		return 0
+	}
+	if efficiency == nil {
+		return 1
+	}
+	return *efficiency
+}
+
+// 运行时设置喷嘴状态，Side 为 wide 或 narrow，不设置 Efficiency 时恢复正常效率
+type NozzleState struct {
+	Side       string   `json:"side"`
+	RollerNum  int      `json:"roller_num"`
+	Disabled   bool     `json:"disabled"`
+	Efficiency *float32 `json:"efficiency,omitempty"`
 }
 
 type Section struct {
@@ -205,3 +276,140 @@ const (
 
 // 元素类型
 type ItemType [Width / YStep][Length / XStep]float32
+
+// 动态轻压下配置
+type SoftReductionCfg struct {
+	StartSolidFraction  float32 `json:"start_solid_fraction"`  // 压下区间起点的中心固相率
+	EndSolidFraction    float32 `json:"end_solid_fraction"`    // 压下区间终点的中心固相率
+	ReductionRate       float32 `json:"reduction_rate"`        // 压下率 mm/m
+	MaxSegmentReduction float32 `json:"max_segment_reduction"` // 单个扇形段最大压下量 mm
+}
+
+// 在线调宽请求
+type WidthChange struct {
+	Width    int     `json:"width"`    // 目标宽度（宽面长度）mm
+	Duration float32 `json:"duration"` // 调宽时间 s，0 表示立即完成
+}
+
+// 动态二冷控制配置，按冷却区表面温度与目标温度的偏差用 PID 调整内弧水量
+type DynamicCoolingCfg struct {
+	Enabled   bool                       `json:"enabled"`
+	Targets   map[int]map[string]float32 `json:"targets"` // 钢种 -> 冷却区名称 -> 目标表面温度
+	Kp        float32                    `json:"kp"`
+	Ki        float32                    `json:"ki"`
+	Kd        float32                    `json:"kd"`
+	MinVolume float32                    `json:"min_volume"` // 冷却区内弧水量下限 L/min
+	MaxVolume float32                    `json:"max_volume"` // 冷却区内弧水量上限 L/min
+	MaxStep   float32                    `json:"max_step"`   // 每个控制周期的最大调整量 L/min
+	Period    float32                    `json:"period"`     // 控制周期，计算时间 s
+}
+
+// 离线冷却制度优化请求，断面为当前计算环境的断面
+type OptimizeCfg struct {
+	SteelValue              int                `json:"steel_value"`
+	Speeds                  []float32          `json:"speeds"`                    // 拉速 m/min，多个拉速时同时生成水表
+	TargetTemperature       map[string]float32 `json:"target_temperature"`        // 冷却区名称 -> 目标表面温度
+	MaxReheatingRate        float32            `json:"max_reheating_rate"`        // 最大回温速率 ℃/m，0 表示不限制
+	MinUnbendingTemperature float32            `json:"min_unbending_temperature"` // 矫直点最低表面温度，0 表示不限制
+	MaxCraterEnd            float32            `json:"max_crater_end"`            // 液芯末端距弯月面的最大距离 mm，0 表示不限制
+	MinVolume               float32            `json:"min_volume"`                // 冷却区内弧水量下限 L/min
+	MaxVolume               float32            `json:"max_volume"`                // 冷却区内弧水量上限 L/min
+	Iterations              int                `json:"iterations"`                // 每个拉速的最大评估次数
+}
+
+// 拉速曲线上的一个点，Time 为距曲线开始的计算时间
+type SpeedPoint struct {
+	Time  float32 `json:"time"`  // s
+	Speed float32 `json:"speed"` // m/min
+}
+
+// 运行时修改单个冷却区的二冷水量，Zone 从 1 开始
+type SecondaryCoolingChange struct {
+	Zone    int                          `json:"zone"`
+	Section SecondaryCoolingWaterSection `json:"section"`
+}
+
+// 中间包温度曲线上的一个点，Time 为距曲线开始的计算时间
+type TundishPoint struct {
+	Time        float32 `json:"time"`        // s
+	Temperature float32 `json:"temperature"` // ℃
+}
+
+// 中间包温度设置，给定温度曲线时按曲线播放，否则从当前浇铸温度按降温速率下降
+type TundishCfg struct {
+	Points      []TundishPoint `json:"points"`
+	CoolingRate float32        `json:"cooling_rate"` // 中间包降温速率 ℃/min，0 表示温度不变
+}
+
+// 换包事件，新钢包开浇后中间包温度变为 Temperature
+type LadleChange struct {
+	Temperature float32 `json:"temperature"`
+}
+
+// 炉次事件类型
+const (
+	HeatOpen        = "open"         // 开浇
+	HeatClose       = "close"        // 停浇
+	HeatLadleChange = "ladle_change" // 换包，关闭上一炉次并开始新炉次
+)
+
+// 炉次事件
+type HeatEvent struct {
+	Type        string  `json:"type"`
+	HeatID      string  `json:"heat_id"`
+	Grade       string  `json:"grade"`       // 钢种
+	Temperature float32 `json:"temperature"` // 开浇或换包时的中间包温度，0 表示不修改浇铸温度
+}
+
+// 结晶器液面测量值
+type MoldLevel struct {
+	Level float32 `json:"level"` // 液面距结晶器上口的距离 mm
+}
+
+// 结晶器液面设定
+type MoldLevelCfg struct {
+	Setpoint float32 `json:"setpoint"` // 液面设定值，距结晶器上口的距离 mm
+	Window   int     `json:"window"`   // 计算液面波动使用的最近测量值个数，0 表示使用默认值
+}
+
+// 测温仪所在的铸坯表面
+const (
+	FaceInner  = "inner"  // 内弧宽面
+	FaceOuter  = "outer"  // 外弧宽面
+	FaceNarrow = "narrow" // 窄面
+)
+
+// 测温仪测量点
+type Pyrometer struct {
+	ID       string  `json:"id"`
+	Distance float32 `json:"distance"` // 距弯月面的铸流位置 mm
+	Face     string  `json:"face"`
+	Offset   float32 `json:"offset"` // 沿表面距铸坯中心线的距离 mm，宽面为宽度方向，窄面为厚度方向
+}
+
+// 测温仪配置，重新设置后清空已有的测量值和统计
+type PyrometerCfg struct {
+	Points []Pyrometer `json:"points"`
+	Window int         `json:"window"` // 统计残差使用的最近比较次数，0 表示使用默认值
+}
+
+// 测温仪测量值
+type PyrometerReading struct {
+	ID          string  `json:"id"`
+	Temperature float32 `json:"temperature"`
+}
+
+// 历史数据类型
+const (
+	HistoryIndicators = "indicators" // 关键指标
+	HistoryField      = "field"      // 降采样后的温度场
+)
+
+// 历史数据查询
+type HistoryQuery struct {
+	Kind   string    `json:"kind"`
+	Caster string    `json:"caster"` // 为空时查询全部铸机
+	Start  time.Time `json:"start"`  // RFC3339
+	End    time.Time `json:"end"`
+	Skip   int       `json:"skip"` // 跳过时间等于 Start 的前 Skip 条记录，与 Start 一起取自上次查询结果的 next 和 skip
+}
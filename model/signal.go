@@ -0,0 +1,118 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 现场信号名称，连接现场数据源时按信号名称配置数据点
+const (
+	SignalSpeed               = "speed"                 // 拉速 m/min
+	SignalNarrowSurfaceIn     = "narrow_surface_in"     // 结晶器窄面进水温度
+	SignalNarrowSurfaceOut    = "narrow_surface_out"    // 结晶器窄面出水温度
+	SignalNarrowSurfaceVolume = "narrow_surface_volume" // 结晶器窄面水量
+	SignalWideSurfaceIn       = "wide_surface_in"       // 结晶器宽面进水温度
+	SignalWideSurfaceOut      = "wide_surface_out"      // 结晶器宽面出水温度
+	SignalWideSurfaceVolume   = "wide_surface_volume"   // 结晶器宽面水量
+	SignalTundishTemperature  = "tundish_temperature"   // 中间包温度
+	SignalMoldLevel           = "mold_level"            // 结晶器液面，距结晶器上口 mm
+
+	// 冷却区内弧水量信号名称前缀，完整名称为 zone_1、zone_2 ...
+	SignalZonePrefix = "zone_"
+	// 测温仪信号名称前缀，完整名称为 pyrometer_ 加测温仪编号
+	SignalPyrometerPrefix = "pyrometer_"
+)
+
+// 除冷却区水量以外的全部信号
+var Signals = []string{
+	SignalSpeed,
+	SignalNarrowSurfaceIn,
+	SignalNarrowSurfaceOut,
+	SignalNarrowSurfaceVolume,
+	SignalWideSurfaceIn,
+	SignalWideSurfaceOut,
+	SignalWideSurfaceVolume,
+	SignalTundishTemperature,
+	SignalMoldLevel,
+}
+
+// 解析冷却区内弧水量信号名称，返回从 1 开始的冷却区编号
+func ZoneSignal(name string) (int, bool) {
+	if !strings.HasPrefix(name, SignalZonePrefix) {
+		return 0, false
+	}
+	zone, err := strconv.Atoi(strings.TrimPrefix(name, SignalZonePrefix))
+	if err != nil || zone < 1 {
+		return 0, false
+	}
+	return zone, true
+}
+
+// 解析测温仪信号名称，返回测温仪编号
+func PyrometerSignal(name string) (string, bool) {
+	if !strings.HasPrefix(name, SignalPyrometerPrefix) || len(name) == len(SignalPyrometerPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(name, SignalPyrometerPrefix), true
+}
+
+// OPC UA 数据源配置
+type OpcUaCfg struct {
+	Endpoint string            `json:"endpoint"` // 如 opc.tcp://localhost:4840
+	Interval float32           `json:"interval"` // 采样周期 s
+	Nodes    map[string]string `json:"nodes"`    // 信号名称 -> 节点 ID，如 ns=2;s=Caster.Speed
+}
+
+// Modbus 寄存器类型
+const (
+	ModbusHolding = "holding" // 保持寄存器，功能码 03
+	ModbusInput   = "input"   // 输入寄存器，功能码 04
+)
+
+// Modbus 寄存器数据类型，32 位数据高字在前
+const (
+	ModbusUint16  = "uint16"
+	ModbusInt16   = "int16"
+	ModbusUint32  = "uint32"
+	ModbusInt32   = "int32"
+	ModbusFloat32 = "float32"
+)
+
+// Modbus 寄存器映射，信号值 = 寄存器值 * Scale + Offset
+type ModbusRegister struct {
+	Signal   string  `json:"signal"`
+	Address  uint16  `json:"address"`
+	Type     string  `json:"type"`      // 寄存器类型，为空时为保持寄存器
+	DataType string  `json:"data_type"` // 数据类型，为空时为 uint16
+	Scale    float32 `json:"scale"`     // 0 表示 1
+	Offset   float32 `json:"offset"`
+	Unit     string  `json:"unit"`
+}
+
+// Modbus TCP 数据源配置
+type ModbusCfg struct {
+	Address      string           `json:"address"` // 如 192.168.1.10:502
+	UnitID       byte             `json:"unit_id"`
+	Interval     float32          `json:"interval"`      // 采样周期 s
+	StaleTimeout float32          `json:"stale_timeout"` // 超过该时间没有读到新值认为信号失效 s，0 表示 3 个采样周期
+	Registers    []ModbusRegister `json:"registers"`
+}
+
+// MQTT 发布主题，为空的主题不发布
+type MqttTopics struct {
+	MoldExitShell          string `json:"mold_exit_shell"`          // 结晶器出口坯壳厚度
+	CraterEnd              string `json:"crater_end"`               // 液芯末端位置
+	ZoneSurfaceTemperature string `json:"zone_surface_temperature"` // 冷却区表面温度
+	Alarms                 string `json:"alarms"`                   // 当前报警
+}
+
+// MQTT 发布配置
+type MqttCfg struct {
+	Broker   string     `json:"broker"` // 如 192.168.1.20:1883
+	ClientID string     `json:"client_id"`
+	Username string     `json:"username"`
+	Password string     `json:"password"`
+	QoS      byte       `json:"qos"`    // 0 或 1
+	Retain   bool       `json:"retain"` // 保留最新值，新订阅者立即收到
+	Topics   MqttTopics `json:"topics"`
+}
@@ -0,0 +1,132 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	log "github.com/sirupsen/logrus"
+	"lz/calculator"
+	"lz/model"
+	"time"
+)
+
+const (
+	mqttKeepAlive     = 60 * time.Second // 心跳间隔
+	mqttTimeout       = 5 * time.Second  // 连接和等待发布确认的超时时间
+	mqttRetryInterval = 5 * time.Second  // 断线后重新连接的最长间隔
+)
+
+var ErrConnectRefused = errors.New("MQTT 服务器拒绝连接")
+
+// 发布的消息内容
+type message struct {
+	Time  time.Time   `json:"time"`
+	Value interface{} `json:"value"`
+}
+
+// MQTT 关键指标发布器，在单独的协程中发布，不阻塞温度场推送
+// QoS 1 时不清除会话，断线期间和未确认的消息保存在客户端，自动重连后带 DUP 标志重发
+type Mqtt struct {
+	cfg    model.MqttCfg
+	client mqtt.Client
+	queue  chan *calculator.Indicators
+}
+
+func NewMqtt(cfg model.MqttCfg) *Mqtt {
+	opts := mqtt.NewClientOptions().
+		AddBroker("tcp://" + cfg.Broker).
+		SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetCleanSession(cfg.QoS == 0).
+		SetKeepAlive(mqttKeepAlive).
+		SetConnectTimeout(mqttTimeout).
+		SetWriteTimeout(mqttTimeout).
+		SetAutoReconnect(true).
+		SetMaxReconnectInterval(mqttRetryInterval).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			log.WithFields(log.Fields{"broker": cfg.Broker, "err": err}).Warn("MQTT 连接断开，自动重连")
+		})
+	return &Mqtt{
+		cfg:    cfg,
+		client: mqtt.NewClient(opts),
+		queue:  make(chan *calculator.Indicators, 1),
+	}
+}
+
+// 连接服务器，需在 Run 之前调用
+func (m *Mqtt) Connect() error {
+	token := m.client.Connect()
+	if !token.WaitTimeout(mqttTimeout) {
+		return fmt.Errorf("连接 MQTT 服务器 %s 超时", m.cfg.Broker)
+	}
+	if err := token.Error(); err != nil {
+		if rc := token.(*mqtt.ConnectToken).ReturnCode(); rc > packets.Accepted && rc <= packets.ErrRefusedNotAuthorised {
+			return fmt.Errorf("%w: 返回码 %d", ErrConnectRefused, rc)
+		}
+		return err
+	}
+	log.WithField("broker", m.cfg.Broker).Info("已连接 MQTT 服务器")
+	return nil
+}
+
+// 提交一次关键指标，上一次还未发布完成时丢弃
+func (m *Mqtt) Publish(indicators *calculator.Indicators) {
+	select {
+	case m.queue <- indicators:
+	default:
+		log.Warn("MQTT 发布繁忙，丢弃本次关键指标")
+	}
+}
+
+// 发布提交的关键指标直到 ctx 取消
+func (m *Mqtt) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			m.client.Disconnect(uint(mqttTimeout / time.Millisecond))
+			log.WithField("broker", m.cfg.Broker).Info("断开 MQTT 服务器")
+			return
+		case indicators := <-m.queue:
+			m.publish(indicators)
+		}
+	}
+}
+
+// 发布到配置了主题的各个指标
+func (m *Mqtt) publish(indicators *calculator.Indicators) {
+	topics := []struct {
+		topic string
+		value interface{}
+	}{
+		{m.cfg.Topics.MoldExitShell, indicators.MoldExitShell},
+		{m.cfg.Topics.CraterEnd, indicators.CraterEnd},
+		{m.cfg.Topics.ZoneSurfaceTemperature, indicators.ZoneSurfaceTemperature},
+		{m.cfg.Topics.Alarms, indicators.Alarms},
+	}
+	for _, t := range topics {
+		if t.topic == "" {
+			continue
+		}
+		payload, err := json.Marshal(message{Time: indicators.Time, Value: t.value})
+		if err != nil {
+			log.WithFields(log.Fields{"topic": t.topic, "err": err}).Error("MQTT 消息json解析失败")
+			continue
+		}
+		token := m.client.Publish(t.topic, m.cfg.QoS, m.cfg.Retain, payload)
+		if !m.client.IsConnectionOpen() {
+			// 重连期间 QoS 1 的消息已保存，连接恢复后重发，不等待确认
+			continue
+		}
+		if !token.WaitTimeout(mqttTimeout) {
+			log.WithField("topic", t.topic).Warn("等待 MQTT 发布确认超时")
+			continue
+		}
+		if err = token.Error(); err != nil {
+			log.WithFields(log.Fields{"topic": t.topic, "err": err}).Error("MQTT 发布失败")
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	mqttserver "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"io"
+	"log/slog"
+	"lz/calculator"
+	"lz/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 收到的一条发布消息
+type published struct {
+	topic   string
+	payload []byte
+	qos     byte
+}
+
+// 本地嵌入式 MQTT 服务器，订阅 caster/# 记录收到的消息
+type broker struct {
+	s        *mqttserver.Server
+	addr     string
+	messages chan published
+	close    sync.Once
+}
+
+// 在 addr 上启动服务器，ledger 为 nil 时允许所有客户端连接
+func startBroker(t *testing.T, addr string, ledger *auth.Ledger) *broker {
+	s := mqttserver.New(&mqttserver.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	var err error
+	if ledger == nil {
+		err = s.AddHook(new(auth.AllowHook), nil)
+	} else {
+		err = s.AddHook(new(auth.Hook), &auth.Options{Ledger: ledger})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := listeners.NewTCP(listeners.Config{ID: "tcp", Address: addr})
+	if err = s.AddListener(l); err != nil {
+		t.Fatal(err)
+	}
+	b := &broker{s: s, addr: l.Address(), messages: make(chan published, 10)}
+	err = s.Subscribe("caster/#", 1, func(_ *mqttserver.Client, _ packets.Subscription, pk packets.Packet) {
+		b.messages <- published{topic: pk.TopicName, payload: pk.Payload, qos: pk.FixedHeader.Qos}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Serve()
+	t.Cleanup(b.stop)
+	return b
+}
+
+func (b *broker) stop() {
+	b.close.Do(func() { b.s.Close() })
+}
+
+func (b *broker) receive(t *testing.T) published {
+	select {
+	case msg := <-b.messages:
+		return msg
+	case <-time.After(15 * time.Second):
+		t.Fatal("没有收到发布消息")
+	}
+	return published{}
+}
+
+func TestMqtt(t *testing.T) {
+	b := startBroker(t, "localhost:0", nil)
+	m := NewMqtt(model.MqttCfg{
+		Broker:   b.addr,
+		ClientID: "caster-1",
+		QoS:      1,
+		Retain:   true,
+		Topics: model.MqttTopics{
+			CraterEnd: "caster/1/crater_end",
+			Alarms:    "caster/1/alarms",
+		},
+	})
+	if err := m.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Run(ctx)
+	m.Publish(&calculator.Indicators{
+		CraterEnd: 21500,
+		Alarms:    []calculator.Alarm{{Type: calculator.AlarmNozzle, Message: "喷嘴 wide 3 严重故障"}},
+	})
+
+	for _, topic := range []string{"caster/1/crater_end", "caster/1/alarms"} {
+		msg := b.receive(t)
+		if msg.topic != topic || msg.qos != 1 {
+			t.Fatalf("发布消息不正确: %+v", msg)
+		}
+		if topic == "caster/1/crater_end" {
+			var payload struct{ Value float32 }
+			if err := json.Unmarshal(msg.payload, &payload); err != nil || payload.Value != 21500 {
+				t.Fatalf("液芯末端消息不正确: %s", msg.payload)
+			}
+		}
+		if len(b.s.Topics.Messages(topic)) != 1 {
+			t.Fatal("消息应保留在服务器上:", topic)
+		}
+	}
+}
+
+func TestMqttResendAfterReconnect(t *testing.T) {
+	b := startBroker(t, "localhost:0", nil)
+	m := NewMqtt(model.MqttCfg{
+		Broker:   b.addr,
+		ClientID: "caster-1",
+		QoS:      1,
+		Topics:   model.MqttTopics{CraterEnd: "caster/1/crater_end"},
+	})
+	if err := m.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Run(ctx)
+
+	// 服务器停止期间发布的消息在重新连接后发送
+	b.stop()
+	deadline := time.Now().Add(5 * time.Second)
+	for m.client.IsConnectionOpen() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	m.Publish(&calculator.Indicators{CraterEnd: 22000})
+	b = startBroker(t, b.addr, nil)
+	msg := b.receive(t)
+	var payload struct{ Value float32 }
+	if err := json.Unmarshal(msg.payload, &payload); err != nil || msg.topic != "caster/1/crater_end" || payload.Value != 22000 {
+		t.Fatalf("重连后应重发断线期间的消息: %+v", msg)
+	}
+}
+
+func TestMqttConnectRefused(t *testing.T) {
+	b := startBroker(t, "localhost:0", &auth.Ledger{Auth: auth.AuthRules{{Username: "lz", Password: "secret", Allow: true}}})
+	m := NewMqtt(model.MqttCfg{Broker: b.addr, Username: "lz", Password: "wrong"})
+	if err := m.Connect(); !errors.Is(err, ErrConnectRefused) {
+		t.Fatal("应返回拒绝连接错误:", err)
+	}
+}
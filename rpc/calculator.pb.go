@@ -0,0 +1,2172 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v5.29.3
+// source: calculator.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationRequest) Reset() {
+	*x = SimulationRequest{}
+	mi := &file_calculator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationRequest) ProtoMessage() {}
+
+func (x *SimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationRequest.ProtoReflect.Descriptor instead.
+func (*SimulationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SimulationRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+type Simulation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Caster        string                 `protobuf:"bytes,2,opt,name=caster,proto3" json:"caster,omitempty"`
+	EnvSet        bool                   `protobuf:"varint,3,opt,name=env_set,json=envSet,proto3" json:"env_set,omitempty"`
+	Running       bool                   `protobuf:"varint,4,opt,name=running,proto3" json:"running,omitempty"`
+	FieldSize     int32                  `protobuf:"varint,5,opt,name=field_size,json=fieldSize,proto3" json:"field_size,omitempty"` // 已生成的切片数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Simulation) Reset() {
+	*x = Simulation{}
+	mi := &file_calculator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Simulation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Simulation) ProtoMessage() {}
+
+func (x *Simulation) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Simulation.ProtoReflect.Descriptor instead.
+func (*Simulation) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Simulation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Simulation) GetCaster() string {
+	if x != nil {
+		return x.Caster
+	}
+	return ""
+}
+
+func (x *Simulation) GetEnvSet() bool {
+	if x != nil {
+		return x.EnvSet
+	}
+	return false
+}
+
+func (x *Simulation) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *Simulation) GetFieldSize() int32 {
+	if x != nil {
+		return x.FieldSize
+	}
+	return 0
+}
+
+type SetEnvRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Env           *Env                   `protobuf:"bytes,2,opt,name=env,proto3" json:"env,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEnvRequest) Reset() {
+	*x = SetEnvRequest{}
+	mi := &file_calculator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEnvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEnvRequest) ProtoMessage() {}
+
+func (x *SetEnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEnvRequest.ProtoReflect.Descriptor instead.
+func (*SetEnvRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetEnvRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *SetEnvRequest) GetEnv() *Env {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+type InitSteelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	SteelValue    int32                  `protobuf:"varint,2,opt,name=steel_value,json=steelValue,proto3" json:"steel_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitSteelRequest) Reset() {
+	*x = InitSteelRequest{}
+	mi := &file_calculator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitSteelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitSteelRequest) ProtoMessage() {}
+
+func (x *InitSteelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitSteelRequest.ProtoReflect.Descriptor instead.
+func (*InitSteelRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InitSteelRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *InitSteelRequest) GetSteelValue() int32 {
+	if x != nil {
+		return x.SteelValue
+	}
+	return 0
+}
+
+type GetSliceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSliceRequest) Reset() {
+	*x = GetSliceRequest{}
+	mi := &file_calculator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSliceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSliceRequest) ProtoMessage() {}
+
+func (x *GetSliceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSliceRequest.ProtoReflect.Descriptor instead.
+func (*GetSliceRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSliceRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *GetSliceRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type GetVerticalSlice2Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`                 // 宽面方向的下标
+	ZScale        int32                  `protobuf:"varint,3,opt,name=z_scale,json=zScale,proto3" json:"z_scale,omitempty"` // 拉坯方向的缩放比例，必须大于 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVerticalSlice2Request) Reset() {
+	*x = GetVerticalSlice2Request{}
+	mi := &file_calculator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVerticalSlice2Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVerticalSlice2Request) ProtoMessage() {}
+
+func (x *GetVerticalSlice2Request) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVerticalSlice2Request.ProtoReflect.Descriptor instead.
+func (*GetVerticalSlice2Request) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetVerticalSlice2Request) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *GetVerticalSlice2Request) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *GetVerticalSlice2Request) GetZScale() int32 {
+	if x != nil {
+		return x.ZScale
+	}
+	return 0
+}
+
+// 计算环境，与 websocket 的 env 消息对应
+type Env struct {
+	state                    protoimpl.MessageState          `protogen:"open.v1"`
+	Caster                   string                          `protobuf:"bytes,1,opt,name=caster,proto3" json:"caster,omitempty"` // 铸机名称，为空时使用已选择的铸机
+	LevelHeight              float32                         `protobuf:"fixed32,2,opt,name=level_height,json=levelHeight,proto3" json:"level_height,omitempty"`
+	SteelValue               int32                           `protobuf:"varint,3,opt,name=steel_value,json=steelValue,proto3" json:"steel_value,omitempty"`
+	StartTemperature         float32                         `protobuf:"fixed32,4,opt,name=start_temperature,json=startTemperature,proto3" json:"start_temperature,omitempty"`
+	Md                       *Md                             `protobuf:"bytes,5,opt,name=md,proto3" json:"md,omitempty"`
+	DragSpeed                float32                         `protobuf:"fixed32,6,opt,name=drag_speed,json=dragSpeed,proto3" json:"drag_speed,omitempty"` // m/min
+	Coordinate               *Coordinate                     `protobuf:"bytes,7,opt,name=coordinate,proto3" json:"coordinate,omitempty"`
+	SecondaryCoolingWaterCfg []*SecondaryCoolingWaterSection `protobuf:"bytes,8,rep,name=secondary_cooling_water_cfg,json=secondaryCoolingWaterCfg,proto3" json:"secondary_cooling_water_cfg,omitempty"`
+	CoolingZoneCfg           []*CoolingZone                  `protobuf:"bytes,9,rep,name=cooling_zone_cfg,json=coolingZoneCfg,proto3" json:"cooling_zone_cfg,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Env) Reset() {
+	*x = Env{}
+	mi := &file_calculator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Env) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Env) ProtoMessage() {}
+
+func (x *Env) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Env.ProtoReflect.Descriptor instead.
+func (*Env) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Env) GetCaster() string {
+	if x != nil {
+		return x.Caster
+	}
+	return ""
+}
+
+func (x *Env) GetLevelHeight() float32 {
+	if x != nil {
+		return x.LevelHeight
+	}
+	return 0
+}
+
+func (x *Env) GetSteelValue() int32 {
+	if x != nil {
+		return x.SteelValue
+	}
+	return 0
+}
+
+func (x *Env) GetStartTemperature() float32 {
+	if x != nil {
+		return x.StartTemperature
+	}
+	return 0
+}
+
+func (x *Env) GetMd() *Md {
+	if x != nil {
+		return x.Md
+	}
+	return nil
+}
+
+func (x *Env) GetDragSpeed() float32 {
+	if x != nil {
+		return x.DragSpeed
+	}
+	return 0
+}
+
+func (x *Env) GetCoordinate() *Coordinate {
+	if x != nil {
+		return x.Coordinate
+	}
+	return nil
+}
+
+func (x *Env) GetSecondaryCoolingWaterCfg() []*SecondaryCoolingWaterSection {
+	if x != nil {
+		return x.SecondaryCoolingWaterCfg
+	}
+	return nil
+}
+
+func (x *Env) GetCoolingZoneCfg() []*CoolingZone {
+	if x != nil {
+		return x.CoolingZoneCfg
+	}
+	return nil
+}
+
+type Coordinate struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	R                   float32                `protobuf:"fixed32,1,opt,name=r,proto3" json:"r,omitempty"`
+	LevelHeight         float32                `protobuf:"fixed32,2,opt,name=level_height,json=levelHeight,proto3" json:"level_height,omitempty"`
+	ArcStartDistance    float32                `protobuf:"fixed32,3,opt,name=arc_start_distance,json=arcStartDistance,proto3" json:"arc_start_distance,omitempty"`
+	ArcEndDistance      float32                `protobuf:"fixed32,4,opt,name=arc_end_distance,json=arcEndDistance,proto3" json:"arc_end_distance,omitempty"`
+	CenterStartDistance float32                `protobuf:"fixed32,5,opt,name=center_start_distance,json=centerStartDistance,proto3" json:"center_start_distance,omitempty"`
+	CenterEndDistance   float32                `protobuf:"fixed32,6,opt,name=center_end_distance,json=centerEndDistance,proto3" json:"center_end_distance,omitempty"`
+	MdLength            int32                  `protobuf:"varint,7,opt,name=md_length,json=mdLength,proto3" json:"md_length,omitempty"`
+	Width               int32                  `protobuf:"varint,8,opt,name=width,proto3" json:"width,omitempty"`
+	Length              int32                  `protobuf:"varint,9,opt,name=length,proto3" json:"length,omitempty"`
+	ZLength             int32                  `protobuf:"varint,10,opt,name=z_length,json=zLength,proto3" json:"z_length,omitempty"`
+	ZScale              int32                  `protobuf:"varint,11,opt,name=z_scale,json=zScale,proto3" json:"z_scale,omitempty"`
+	XScale              int32                  `protobuf:"varint,12,opt,name=x_scale,json=xScale,proto3" json:"x_scale,omitempty"`
+	YScale              int32                  `protobuf:"varint,13,opt,name=y_scale,json=yScale,proto3" json:"y_scale,omitempty"`
+	BendingZones        []*BendingZone         `protobuf:"bytes,14,rep,name=bending_zones,json=bendingZones,proto3" json:"bending_zones,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Coordinate) Reset() {
+	*x = Coordinate{}
+	mi := &file_calculator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinate) ProtoMessage() {}
+
+func (x *Coordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinate.ProtoReflect.Descriptor instead.
+func (*Coordinate) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Coordinate) GetR() float32 {
+	if x != nil {
+		return x.R
+	}
+	return 0
+}
+
+func (x *Coordinate) GetLevelHeight() float32 {
+	if x != nil {
+		return x.LevelHeight
+	}
+	return 0
+}
+
+func (x *Coordinate) GetArcStartDistance() float32 {
+	if x != nil {
+		return x.ArcStartDistance
+	}
+	return 0
+}
+
+func (x *Coordinate) GetArcEndDistance() float32 {
+	if x != nil {
+		return x.ArcEndDistance
+	}
+	return 0
+}
+
+func (x *Coordinate) GetCenterStartDistance() float32 {
+	if x != nil {
+		return x.CenterStartDistance
+	}
+	return 0
+}
+
+func (x *Coordinate) GetCenterEndDistance() float32 {
+	if x != nil {
+		return x.CenterEndDistance
+	}
+	return 0
+}
+
+func (x *Coordinate) GetMdLength() int32 {
+	if x != nil {
+		return x.MdLength
+	}
+	return 0
+}
+
+func (x *Coordinate) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Coordinate) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Coordinate) GetZLength() int32 {
+	if x != nil {
+		return x.ZLength
+	}
+	return 0
+}
+
+func (x *Coordinate) GetZScale() int32 {
+	if x != nil {
+		return x.ZScale
+	}
+	return 0
+}
+
+func (x *Coordinate) GetXScale() int32 {
+	if x != nil {
+		return x.XScale
+	}
+	return 0
+}
+
+func (x *Coordinate) GetYScale() int32 {
+	if x != nil {
+		return x.YScale
+	}
+	return 0
+}
+
+func (x *Coordinate) GetBendingZones() []*BendingZone {
+	if x != nil {
+		return x.BendingZones
+	}
+	return nil
+}
+
+type BendingZone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDistance float32                `protobuf:"fixed32,1,opt,name=start_distance,json=startDistance,proto3" json:"start_distance,omitempty"`
+	EndDistance   float32                `protobuf:"fixed32,2,opt,name=end_distance,json=endDistance,proto3" json:"end_distance,omitempty"`
+	StartRadius   float32                `protobuf:"fixed32,3,opt,name=start_radius,json=startRadius,proto3" json:"start_radius,omitempty"`
+	EndRadius     float32                `protobuf:"fixed32,4,opt,name=end_radius,json=endRadius,proto3" json:"end_radius,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BendingZone) Reset() {
+	*x = BendingZone{}
+	mi := &file_calculator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BendingZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BendingZone) ProtoMessage() {}
+
+func (x *BendingZone) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BendingZone.ProtoReflect.Descriptor instead.
+func (*BendingZone) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BendingZone) GetStartDistance() float32 {
+	if x != nil {
+		return x.StartDistance
+	}
+	return 0
+}
+
+func (x *BendingZone) GetEndDistance() float32 {
+	if x != nil {
+		return x.EndDistance
+	}
+	return 0
+}
+
+func (x *BendingZone) GetStartRadius() float32 {
+	if x != nil {
+		return x.StartRadius
+	}
+	return 0
+}
+
+func (x *BendingZone) GetEndRadius() float32 {
+	if x != nil {
+		return x.EndRadius
+	}
+	return 0
+}
+
+type Md struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	NarrowSurfaceIn     float32                `protobuf:"fixed32,1,opt,name=narrow_surface_in,json=narrowSurfaceIn,proto3" json:"narrow_surface_in,omitempty"`
+	NarrowSurfaceOut    float32                `protobuf:"fixed32,2,opt,name=narrow_surface_out,json=narrowSurfaceOut,proto3" json:"narrow_surface_out,omitempty"`
+	NarrowSurfaceVolume float32                `protobuf:"fixed32,3,opt,name=narrow_surface_volume,json=narrowSurfaceVolume,proto3" json:"narrow_surface_volume,omitempty"`
+	WideSurfaceIn       float32                `protobuf:"fixed32,4,opt,name=wide_surface_in,json=wideSurfaceIn,proto3" json:"wide_surface_in,omitempty"`
+	WideSurfaceOut      float32                `protobuf:"fixed32,5,opt,name=wide_surface_out,json=wideSurfaceOut,proto3" json:"wide_surface_out,omitempty"`
+	WideSurfaceVolume   float32                `protobuf:"fixed32,6,opt,name=wide_surface_volume,json=wideSurfaceVolume,proto3" json:"wide_surface_volume,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Md) Reset() {
+	*x = Md{}
+	mi := &file_calculator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Md) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Md) ProtoMessage() {}
+
+func (x *Md) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Md.ProtoReflect.Descriptor instead.
+func (*Md) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Md) GetNarrowSurfaceIn() float32 {
+	if x != nil {
+		return x.NarrowSurfaceIn
+	}
+	return 0
+}
+
+func (x *Md) GetNarrowSurfaceOut() float32 {
+	if x != nil {
+		return x.NarrowSurfaceOut
+	}
+	return 0
+}
+
+func (x *Md) GetNarrowSurfaceVolume() float32 {
+	if x != nil {
+		return x.NarrowSurfaceVolume
+	}
+	return 0
+}
+
+func (x *Md) GetWideSurfaceIn() float32 {
+	if x != nil {
+		return x.WideSurfaceIn
+	}
+	return 0
+}
+
+func (x *Md) GetWideSurfaceOut() float32 {
+	if x != nil {
+		return x.WideSurfaceOut
+	}
+	return 0
+}
+
+func (x *Md) GetWideSurfaceVolume() float32 {
+	if x != nil {
+		return x.WideSurfaceVolume
+	}
+	return 0
+}
+
+type SecondaryCoolingWaterSection struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SprayWaterTemperature float32                `protobuf:"fixed32,1,opt,name=spray_water_temperature,json=sprayWaterTemperature,proto3" json:"spray_water_temperature,omitempty"`
+	InnerArcWaterVolume   float32                `protobuf:"fixed32,2,opt,name=inner_arc_water_volume,json=innerArcWaterVolume,proto3" json:"inner_arc_water_volume,omitempty"`
+	NarrowSideWaterVolume float32                `protobuf:"fixed32,3,opt,name=narrow_side_water_volume,json=narrowSideWaterVolume,proto3" json:"narrow_side_water_volume,omitempty"`
+	Fuqie_1Volume         float32                `protobuf:"fixed32,4,opt,name=fuqie_1_volume,json=fuqie1Volume,proto3" json:"fuqie_1_volume,omitempty"`
+	Fuqie_2Volume         float32                `protobuf:"fixed32,5,opt,name=fuqie_2_volume,json=fuqie2Volume,proto3" json:"fuqie_2_volume,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SecondaryCoolingWaterSection) Reset() {
+	*x = SecondaryCoolingWaterSection{}
+	mi := &file_calculator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecondaryCoolingWaterSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecondaryCoolingWaterSection) ProtoMessage() {}
+
+func (x *SecondaryCoolingWaterSection) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecondaryCoolingWaterSection.ProtoReflect.Descriptor instead.
+func (*SecondaryCoolingWaterSection) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SecondaryCoolingWaterSection) GetSprayWaterTemperature() float32 {
+	if x != nil {
+		return x.SprayWaterTemperature
+	}
+	return 0
+}
+
+func (x *SecondaryCoolingWaterSection) GetInnerArcWaterVolume() float32 {
+	if x != nil {
+		return x.InnerArcWaterVolume
+	}
+	return 0
+}
+
+func (x *SecondaryCoolingWaterSection) GetNarrowSideWaterVolume() float32 {
+	if x != nil {
+		return x.NarrowSideWaterVolume
+	}
+	return 0
+}
+
+func (x *SecondaryCoolingWaterSection) GetFuqie_1Volume() float32 {
+	if x != nil {
+		return x.Fuqie_1Volume
+	}
+	return 0
+}
+
+func (x *SecondaryCoolingWaterSection) GetFuqie_2Volume() float32 {
+	if x != nil {
+		return x.Fuqie_2Volume
+	}
+	return 0
+}
+
+type CoolingZone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneName      string                 `protobuf:"bytes,1,opt,name=zone_name,json=zoneName,proto3" json:"zone_name,omitempty"`
+	Start         int32                  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Medium        int32                  `protobuf:"varint,4,opt,name=medium,proto3" json:"medium,omitempty"`
+	EndDistance   float32                `protobuf:"fixed32,5,opt,name=end_distance,json=endDistance,proto3" json:"end_distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoolingZone) Reset() {
+	*x = CoolingZone{}
+	mi := &file_calculator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoolingZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoolingZone) ProtoMessage() {}
+
+func (x *CoolingZone) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoolingZone.ProtoReflect.Descriptor instead.
+func (*CoolingZone) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CoolingZone) GetZoneName() string {
+	if x != nil {
+		return x.ZoneName
+	}
+	return ""
+}
+
+func (x *CoolingZone) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *CoolingZone) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *CoolingZone) GetMedium() int32 {
+	if x != nil {
+		return x.Medium
+	}
+	return 0
+}
+
+func (x *CoolingZone) GetEndDistance() float32 {
+	if x != nil {
+		return x.EndDistance
+	}
+	return 0
+}
+
+// 二维数组的一行
+type Row struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	mi := &file_calculator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Row) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// 曲线上的点，x 为距弯月面的距离
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float32                `protobuf:"fixed32,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float32                `protobuf:"fixed32,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_calculator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Point) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// 周期性推送的温度场，与 websocket 的 data_push 消息对应
+type TemperatureField struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	XScale          int32                  `protobuf:"varint,1,opt,name=x_scale,json=xScale,proto3" json:"x_scale,omitempty"`
+	YScale          int32                  `protobuf:"varint,2,opt,name=y_scale,json=yScale,proto3" json:"y_scale,omitempty"`
+	ZScale          int32                  `protobuf:"varint,3,opt,name=z_scale,json=zScale,proto3" json:"z_scale,omitempty"`
+	Start           int32                  `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
+	End             int32                  `protobuf:"varint,5,opt,name=end,proto3" json:"end,omitempty"`
+	IsFull          bool                   `protobuf:"varint,6,opt,name=is_full,json=isFull,proto3" json:"is_full,omitempty"`
+	IsTail          bool                   `protobuf:"varint,7,opt,name=is_tail,json=isTail,proto3" json:"is_tail,omitempty"`
+	Sides           *Sides                 `protobuf:"bytes,8,opt,name=sides,proto3" json:"sides,omitempty"`
+	Widths          []float32              `protobuf:"fixed32,9,rep,packed,name=widths,proto3" json:"widths,omitempty"`
+	WidthTransition *WidthTransition       `protobuf:"bytes,10,opt,name=width_transition,json=widthTransition,proto3" json:"width_transition,omitempty"` // 没有调宽过渡区时为空
+	Geometry        []*GeometryPoint       `protobuf:"bytes,11,rep,name=geometry,proto3" json:"geometry,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TemperatureField) Reset() {
+	*x = TemperatureField{}
+	mi := &file_calculator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemperatureField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemperatureField) ProtoMessage() {}
+
+func (x *TemperatureField) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemperatureField.ProtoReflect.Descriptor instead.
+func (*TemperatureField) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TemperatureField) GetXScale() int32 {
+	if x != nil {
+		return x.XScale
+	}
+	return 0
+}
+
+func (x *TemperatureField) GetYScale() int32 {
+	if x != nil {
+		return x.YScale
+	}
+	return 0
+}
+
+func (x *TemperatureField) GetZScale() int32 {
+	if x != nil {
+		return x.ZScale
+	}
+	return 0
+}
+
+func (x *TemperatureField) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TemperatureField) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *TemperatureField) GetIsFull() bool {
+	if x != nil {
+		return x.IsFull
+	}
+	return false
+}
+
+func (x *TemperatureField) GetIsTail() bool {
+	if x != nil {
+		return x.IsTail
+	}
+	return false
+}
+
+func (x *TemperatureField) GetSides() *Sides {
+	if x != nil {
+		return x.Sides
+	}
+	return nil
+}
+
+func (x *TemperatureField) GetWidths() []float32 {
+	if x != nil {
+		return x.Widths
+	}
+	return nil
+}
+
+func (x *TemperatureField) GetWidthTransition() *WidthTransition {
+	if x != nil {
+		return x.WidthTransition
+	}
+	return nil
+}
+
+func (x *TemperatureField) GetGeometry() []*GeometryPoint {
+	if x != nil {
+		return x.Geometry
+	}
+	return nil
+}
+
+type Sides struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Up            []*Row                 `protobuf:"bytes,1,rep,name=up,proto3" json:"up,omitempty"`
+	Left          []*Row                 `protobuf:"bytes,2,rep,name=left,proto3" json:"left,omitempty"`
+	Right         []*Row                 `protobuf:"bytes,3,rep,name=right,proto3" json:"right,omitempty"`
+	Front         []*Row                 `protobuf:"bytes,4,rep,name=front,proto3" json:"front,omitempty"`
+	Back          []*Row                 `protobuf:"bytes,5,rep,name=back,proto3" json:"back,omitempty"`
+	Down          []*Row                 `protobuf:"bytes,6,rep,name=down,proto3" json:"down,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sides) Reset() {
+	*x = Sides{}
+	mi := &file_calculator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sides) ProtoMessage() {}
+
+func (x *Sides) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sides.ProtoReflect.Descriptor instead.
+func (*Sides) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Sides) GetUp() []*Row {
+	if x != nil {
+		return x.Up
+	}
+	return nil
+}
+
+func (x *Sides) GetLeft() []*Row {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *Sides) GetRight() []*Row {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+func (x *Sides) GetFront() []*Row {
+	if x != nil {
+		return x.Front
+	}
+	return nil
+}
+
+func (x *Sides) GetBack() []*Row {
+	if x != nil {
+		return x.Back
+	}
+	return nil
+}
+
+func (x *Sides) GetDown() []*Row {
+	if x != nil {
+		return x.Down
+	}
+	return nil
+}
+
+type WidthTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDistance float32                `protobuf:"fixed32,1,opt,name=start_distance,json=startDistance,proto3" json:"start_distance,omitempty"`
+	EndDistance   float32                `protobuf:"fixed32,2,opt,name=end_distance,json=endDistance,proto3" json:"end_distance,omitempty"`
+	FromWidth     float32                `protobuf:"fixed32,3,opt,name=from_width,json=fromWidth,proto3" json:"from_width,omitempty"`
+	ToWidth       float32                `protobuf:"fixed32,4,opt,name=to_width,json=toWidth,proto3" json:"to_width,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WidthTransition) Reset() {
+	*x = WidthTransition{}
+	mi := &file_calculator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WidthTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WidthTransition) ProtoMessage() {}
+
+func (x *WidthTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WidthTransition.ProtoReflect.Descriptor instead.
+func (*WidthTransition) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WidthTransition) GetStartDistance() float32 {
+	if x != nil {
+		return x.StartDistance
+	}
+	return 0
+}
+
+func (x *WidthTransition) GetEndDistance() float32 {
+	if x != nil {
+		return x.EndDistance
+	}
+	return 0
+}
+
+func (x *WidthTransition) GetFromWidth() float32 {
+	if x != nil {
+		return x.FromWidth
+	}
+	return 0
+}
+
+func (x *WidthTransition) GetToWidth() float32 {
+	if x != nil {
+		return x.ToWidth
+	}
+	return 0
+}
+
+type GeometryPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Distance      float32                `protobuf:"fixed32,1,opt,name=distance,proto3" json:"distance,omitempty"`
+	X             float32                `protobuf:"fixed32,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float32                `protobuf:"fixed32,3,opt,name=y,proto3" json:"y,omitempty"`
+	Z             float32                `protobuf:"fixed32,4,opt,name=z,proto3" json:"z,omitempty"`
+	Angle         float32                `protobuf:"fixed32,5,opt,name=angle,proto3" json:"angle,omitempty"` // 与竖直方向的夹角，单位度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeometryPoint) Reset() {
+	*x = GeometryPoint{}
+	mi := &file_calculator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeometryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeometryPoint) ProtoMessage() {}
+
+func (x *GeometryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeometryPoint.ProtoReflect.Descriptor instead.
+func (*GeometryPoint) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GeometryPoint) GetDistance() float32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *GeometryPoint) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *GeometryPoint) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *GeometryPoint) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *GeometryPoint) GetAngle() float32 {
+	if x != nil {
+		return x.Angle
+	}
+	return 0
+}
+
+type SliceInfo struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	HorizontalSolidThickness  float32                `protobuf:"fixed32,1,opt,name=horizontal_solid_thickness,json=horizontalSolidThickness,proto3" json:"horizontal_solid_thickness,omitempty"`
+	VerticalSolidThickness    float32                `protobuf:"fixed32,2,opt,name=vertical_solid_thickness,json=verticalSolidThickness,proto3" json:"vertical_solid_thickness,omitempty"`
+	HorizontalLiquidThickness float32                `protobuf:"fixed32,3,opt,name=horizontal_liquid_thickness,json=horizontalLiquidThickness,proto3" json:"horizontal_liquid_thickness,omitempty"`
+	VerticalLiquidThickness   float32                `protobuf:"fixed32,4,opt,name=vertical_liquid_thickness,json=verticalLiquidThickness,proto3" json:"vertical_liquid_thickness,omitempty"`
+	Slice                     []*Row                 `protobuf:"bytes,5,rep,name=slice,proto3" json:"slice,omitempty"`
+	Length                    int32                  `protobuf:"varint,6,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *SliceInfo) Reset() {
+	*x = SliceInfo{}
+	mi := &file_calculator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SliceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SliceInfo) ProtoMessage() {}
+
+func (x *SliceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SliceInfo.ProtoReflect.Descriptor instead.
+func (*SliceInfo) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SliceInfo) GetHorizontalSolidThickness() float32 {
+	if x != nil {
+		return x.HorizontalSolidThickness
+	}
+	return 0
+}
+
+func (x *SliceInfo) GetVerticalSolidThickness() float32 {
+	if x != nil {
+		return x.VerticalSolidThickness
+	}
+	return 0
+}
+
+func (x *SliceInfo) GetHorizontalLiquidThickness() float32 {
+	if x != nil {
+		return x.HorizontalLiquidThickness
+	}
+	return 0
+}
+
+func (x *SliceInfo) GetVerticalLiquidThickness() float32 {
+	if x != nil {
+		return x.VerticalLiquidThickness
+	}
+	return 0
+}
+
+func (x *SliceInfo) GetSlice() []*Row {
+	if x != nil {
+		return x.Slice
+	}
+	return nil
+}
+
+func (x *SliceInfo) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type VerticalSlice1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CenterOuter   []*Point               `protobuf:"bytes,1,rep,name=center_outer,json=centerOuter,proto3" json:"center_outer,omitempty"`
+	CenterInner   []*Point               `protobuf:"bytes,2,rep,name=center_inner,json=centerInner,proto3" json:"center_inner,omitempty"`
+	EdgeOuter     []*Point               `protobuf:"bytes,3,rep,name=edge_outer,json=edgeOuter,proto3" json:"edge_outer,omitempty"`
+	EdgeInner     []*Point               `protobuf:"bytes,4,rep,name=edge_inner,json=edgeInner,proto3" json:"edge_inner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerticalSlice1) Reset() {
+	*x = VerticalSlice1{}
+	mi := &file_calculator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerticalSlice1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerticalSlice1) ProtoMessage() {}
+
+func (x *VerticalSlice1) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerticalSlice1.ProtoReflect.Descriptor instead.
+func (*VerticalSlice1) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VerticalSlice1) GetCenterOuter() []*Point {
+	if x != nil {
+		return x.CenterOuter
+	}
+	return nil
+}
+
+func (x *VerticalSlice1) GetCenterInner() []*Point {
+	if x != nil {
+		return x.CenterInner
+	}
+	return nil
+}
+
+func (x *VerticalSlice1) GetEdgeOuter() []*Point {
+	if x != nil {
+		return x.EdgeOuter
+	}
+	return nil
+}
+
+func (x *VerticalSlice1) GetEdgeInner() []*Point {
+	if x != nil {
+		return x.EdgeInner
+	}
+	return nil
+}
+
+type Join struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsJoin        bool                   `protobuf:"varint,1,opt,name=is_join,json=isJoin,proto3" json:"is_join,omitempty"`
+	JoinIndex     int32                  `protobuf:"varint,2,opt,name=join_index,json=joinIndex,proto3" json:"join_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Join) Reset() {
+	*x = Join{}
+	mi := &file_calculator_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Join) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Join) ProtoMessage() {}
+
+func (x *Join) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Join.ProtoReflect.Descriptor instead.
+func (*Join) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Join) GetIsJoin() bool {
+	if x != nil {
+		return x.IsJoin
+	}
+	return false
+}
+
+func (x *Join) GetJoinIndex() int32 {
+	if x != nil {
+		return x.JoinIndex
+	}
+	return 0
+}
+
+type VerticalSlice2 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        int32                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	VerticalSlice []*Row                 `protobuf:"bytes,2,rep,name=vertical_slice,json=verticalSlice,proto3" json:"vertical_slice,omitempty"`
+	Solid         []float32              `protobuf:"fixed32,3,rep,packed,name=solid,proto3" json:"solid,omitempty"`
+	Liquid        []float32              `protobuf:"fixed32,4,rep,packed,name=liquid,proto3" json:"liquid,omitempty"`
+	SolidJoin     *Join                  `protobuf:"bytes,5,opt,name=solid_join,json=solidJoin,proto3" json:"solid_join,omitempty"`
+	LiquidJoin    *Join                  `protobuf:"bytes,6,opt,name=liquid_join,json=liquidJoin,proto3" json:"liquid_join,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerticalSlice2) Reset() {
+	*x = VerticalSlice2{}
+	mi := &file_calculator_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerticalSlice2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerticalSlice2) ProtoMessage() {}
+
+func (x *VerticalSlice2) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerticalSlice2.ProtoReflect.Descriptor instead.
+func (*VerticalSlice2) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerticalSlice2) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *VerticalSlice2) GetVerticalSlice() []*Row {
+	if x != nil {
+		return x.VerticalSlice
+	}
+	return nil
+}
+
+func (x *VerticalSlice2) GetSolid() []float32 {
+	if x != nil {
+		return x.Solid
+	}
+	return nil
+}
+
+func (x *VerticalSlice2) GetLiquid() []float32 {
+	if x != nil {
+		return x.Liquid
+	}
+	return nil
+}
+
+func (x *VerticalSlice2) GetSolidJoin() *Join {
+	if x != nil {
+		return x.SolidJoin
+	}
+	return nil
+}
+
+func (x *VerticalSlice2) GetLiquidJoin() *Join {
+	if x != nil {
+		return x.LiquidJoin
+	}
+	return nil
+}
+
+type ShellCurves struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WideShellWidth    []*Point               `protobuf:"bytes,1,rep,name=wide_shell_width,json=wideShellWidth,proto3" json:"wide_shell_width,omitempty"`
+	WideLiquidWidth   []*Point               `protobuf:"bytes,2,rep,name=wide_liquid_width,json=wideLiquidWidth,proto3" json:"wide_liquid_width,omitempty"`
+	NarrowShellWidth  []*Point               `protobuf:"bytes,3,rep,name=narrow_shell_width,json=narrowShellWidth,proto3" json:"narrow_shell_width,omitempty"`
+	NarrowLiquidWidth []*Point               `protobuf:"bytes,4,rep,name=narrow_liquid_width,json=narrowLiquidWidth,proto3" json:"narrow_liquid_width,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ShellCurves) Reset() {
+	*x = ShellCurves{}
+	mi := &file_calculator_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellCurves) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellCurves) ProtoMessage() {}
+
+func (x *ShellCurves) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellCurves.ProtoReflect.Descriptor instead.
+func (*ShellCurves) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ShellCurves) GetWideShellWidth() []*Point {
+	if x != nil {
+		return x.WideShellWidth
+	}
+	return nil
+}
+
+func (x *ShellCurves) GetWideLiquidWidth() []*Point {
+	if x != nil {
+		return x.WideLiquidWidth
+	}
+	return nil
+}
+
+func (x *ShellCurves) GetNarrowShellWidth() []*Point {
+	if x != nil {
+		return x.NarrowShellWidth
+	}
+	return nil
+}
+
+func (x *ShellCurves) GetNarrowLiquidWidth() []*Point {
+	if x != nil {
+		return x.NarrowLiquidWidth
+	}
+	return nil
+}
+
+var File_calculator_proto protoreflect.FileDescriptor
+
+var file_calculator_proto_rawDesc = string([]byte{
+	0x0a, 0x10, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x38, 0x0a, 0x11, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x22, 0x86, 0x01, 0x0a, 0x0a, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x6e, 0x76, 0x5f,
+	0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x76, 0x53, 0x65,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x53, 0x0a, 0x0d, 0x53, 0x65,
+	0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x1d, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6e, 0x76, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x22,
+	0x58, 0x0a, 0x10, 0x49, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x65, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x65, 0x65,
+	0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73,
+	0x74, 0x65, 0x65, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4c, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x53, 0x6c, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x6e, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x56, 0x65,
+	0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x32, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x17,
+	0x0a, 0x07, 0x7a, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x7a, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x22, 0xa1, 0x03, 0x0a, 0x03, 0x45, 0x6e, 0x76, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74,
+	0x65, 0x65, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x73, 0x74, 0x65, 0x65, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x02, 0x6d, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x64,
+	0x52, 0x02, 0x6d, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x72, 0x61, 0x67, 0x5f, 0x73, 0x70, 0x65,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x64, 0x72, 0x61, 0x67, 0x53, 0x70,
+	0x65, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x63, 0x6f, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x63, 0x0a, 0x1b, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x61, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x6f, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x61, 0x74,
+	0x65, 0x72, 0x5f, 0x63, 0x66, 0x67, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c,
+	0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x43,
+	0x6f, 0x6f, 0x6c, 0x69, 0x6e, 0x67, 0x57, 0x61, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x18, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x43, 0x6f, 0x6f,
+	0x6c, 0x69, 0x6e, 0x67, 0x57, 0x61, 0x74, 0x65, 0x72, 0x43, 0x66, 0x67, 0x12, 0x3d, 0x0a, 0x10,
+	0x63, 0x6f, 0x6f, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x63, 0x66, 0x67,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x43, 0x6f, 0x6f, 0x6c, 0x69, 0x6e, 0x67, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x0e, 0x63, 0x6f, 0x6f,
+	0x6c, 0x69, 0x6e, 0x67, 0x5a, 0x6f, 0x6e, 0x65, 0x43, 0x66, 0x67, 0x22, 0xe4, 0x03, 0x0a, 0x0a,
+	0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x61,
+	0x72, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10, 0x61, 0x72, 0x63, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x72, 0x63,
+	0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x0e, 0x61, 0x72, 0x63, 0x45, 0x6e, 0x64, 0x44, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x13, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x64, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x64, 0x5f, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x64, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x7a, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x7a, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x17, 0x0a,
+	0x07, 0x7a, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x7a, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x78, 0x5f, 0x73, 0x63, 0x61, 0x6c,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x78, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x79, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x79, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x0d, 0x62, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x0c, 0x62, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5a, 0x6f, 0x6e,
+	0x65, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x42, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5a, 0x6f,
+	0x6e, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x64,
+	0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x0b, 0x65, 0x6e, 0x64, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x52, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0x94,
+	0x02, 0x0a, 0x02, 0x4d, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f,
+	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x0f, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49,
+	0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x73, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10, 0x6e,
+	0x61, 0x72, 0x72, 0x6f, 0x77, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4f, 0x75, 0x74, 0x12,
+	0x32, 0x0a, 0x15, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x13,
+	0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x77, 0x69, 0x64, 0x65, 0x5f, 0x73, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x77, 0x69,
+	0x64, 0x65, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x77,
+	0x69, 0x64, 0x65, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0e, 0x77, 0x69, 0x64, 0x65, 0x53, 0x75, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x4f, 0x75, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x77, 0x69, 0x64, 0x65, 0x5f, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x11, 0x77, 0x69, 0x64, 0x65, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22, 0x90, 0x02, 0x0a, 0x1c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x61, 0x72, 0x79, 0x43, 0x6f, 0x6f, 0x6c, 0x69, 0x6e, 0x67, 0x57, 0x61, 0x74, 0x65, 0x72, 0x53,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x70, 0x72, 0x61, 0x79, 0x5f,
+	0x77, 0x61, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x15, 0x73, 0x70, 0x72, 0x61, 0x79, 0x57, 0x61,
+	0x74, 0x65, 0x72, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x33,
+	0x0a, 0x16, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x72, 0x63, 0x5f, 0x77, 0x61, 0x74, 0x65,
+	0x72, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x13,
+	0x69, 0x6e, 0x6e, 0x65, 0x72, 0x41, 0x72, 0x63, 0x57, 0x61, 0x74, 0x65, 0x72, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x73, 0x69,
+	0x64, 0x65, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x15, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x53, 0x69, 0x64,
+	0x65, 0x57, 0x61, 0x74, 0x65, 0x72, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e,
+	0x66, 0x75, 0x71, 0x69, 0x65, 0x5f, 0x31, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x66, 0x75, 0x71, 0x69, 0x65, 0x31, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x66, 0x75, 0x71, 0x69, 0x65, 0x5f, 0x32, 0x5f, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x66, 0x75, 0x71, 0x69,
+	0x65, 0x32, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6f,
+	0x6c, 0x69, 0x6e, 0x67, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x7a, 0x6f, 0x6e, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x7a, 0x6f, 0x6e,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x64, 0x69, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d,
+	0x65, 0x64, 0x69, 0x75, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x65, 0x6e, 0x64,
+	0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x1d, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x23, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c,
+	0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x79, 0x22, 0xeb, 0x02, 0x0a,
+	0x10, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x78, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x78, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x79, 0x5f,
+	0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x79, 0x53, 0x63,
+	0x61, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x7a, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x46, 0x75, 0x6c, 0x6c, 0x12, 0x17, 0x0a,
+	0x07, 0x69, 0x73, 0x5f, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x69, 0x73, 0x54, 0x61, 0x69, 0x6c, 0x12, 0x23, 0x0a, 0x05, 0x73, 0x69, 0x64, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x69, 0x64, 0x65, 0x73, 0x52, 0x05, 0x73, 0x69, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x73, 0x12, 0x42, 0x0a, 0x10, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x69, 0x64, 0x74, 0x68, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x08, 0x67, 0x65, 0x6f, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x7a, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x08, 0x67, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x22, 0xcd, 0x01, 0x0a, 0x05, 0x53,
+	0x69, 0x64, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x02, 0x75, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x02, 0x75,
+	0x70, 0x12, 0x1f, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x6c, 0x65,
+	0x66, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x05,
+	0x72, 0x69, 0x67, 0x68, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f,
+	0x77, 0x52, 0x05, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x62, 0x61, 0x63, 0x6b,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x52, 0x6f, 0x77, 0x52, 0x04, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1f, 0x0a, 0x04, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x64, 0x6f, 0x77, 0x6e, 0x22, 0x95, 0x01, 0x0a, 0x0f, 0x57,
+	0x69, 0x64, 0x74, 0x68, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25,
+	0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x65, 0x6e, 0x64,
+	0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x66, 0x72,
+	0x6f, 0x6d, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x74, 0x6f, 0x57, 0x69, 0x64,
+	0x74, 0x68, 0x22, 0x6b, 0x0a, 0x0d, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6e, 0x67,
+	0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x22,
+	0xba, 0x02, 0x0a, 0x09, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x3c, 0x0a,
+	0x1a, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x6f, 0x6c, 0x69,
+	0x64, 0x5f, 0x74, 0x68, 0x69, 0x63, 0x6b, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x18, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x53, 0x6f, 0x6c,
+	0x69, 0x64, 0x54, 0x68, 0x69, 0x63, 0x6b, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x76,
+	0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x5f, 0x74, 0x68,
+	0x69, 0x63, 0x6b, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x16, 0x76,
+	0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6f, 0x6c, 0x69, 0x64, 0x54, 0x68, 0x69, 0x63,
+	0x6b, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x3e, 0x0a, 0x1b, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e,
+	0x74, 0x61, 0x6c, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x5f, 0x74, 0x68, 0x69, 0x63, 0x6b,
+	0x6e, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x19, 0x68, 0x6f, 0x72, 0x69,
+	0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x54, 0x68, 0x69, 0x63,
+	0x6b, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61,
+	0x6c, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x5f, 0x74, 0x68, 0x69, 0x63, 0x6b, 0x6e, 0x65,
+	0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x17, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63,
+	0x61, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x54, 0x68, 0x69, 0x63, 0x6b, 0x6e, 0x65, 0x73,
+	0x73, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x05, 0x73,
+	0x6c, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xd0, 0x01, 0x0a,
+	0x0e, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x31, 0x12,
+	0x30, 0x0a, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x65,
+	0x72, 0x12, 0x30, 0x0a, 0x0c, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x49, 0x6e,
+	0x6e, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x65, 0x64, 0x67, 0x65, 0x4f, 0x75, 0x74, 0x65,
+	0x72, 0x12, 0x2c, 0x0a, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x65, 0x64, 0x67, 0x65, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x22,
+	0x3e, 0x0a, 0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x6a, 0x6f,
+	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x4a, 0x6f, 0x69, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0xe6, 0x01, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63,
+	0x65, 0x32, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x32, 0x0a, 0x0e, 0x76, 0x65,
+	0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f, 0x77, 0x52,
+	0x0d, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x05, 0x73,
+	0x6f, 0x6c, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x0a,
+	0x73, 0x6f, 0x6c, 0x69, 0x64, 0x5f, 0x6a, 0x6f, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x09,
+	0x73, 0x6f, 0x6c, 0x69, 0x64, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x2d, 0x0a, 0x0b, 0x6c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x5f, 0x6a, 0x6f, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x0a, 0x6c, 0x69,
+	0x71, 0x75, 0x69, 0x64, 0x4a, 0x6f, 0x69, 0x6e, 0x22, 0xfd, 0x01, 0x0a, 0x0b, 0x53, 0x68, 0x65,
+	0x6c, 0x6c, 0x43, 0x75, 0x72, 0x76, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x10, 0x77, 0x69, 0x64, 0x65,
+	0x5f, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x0e, 0x77, 0x69, 0x64, 0x65, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x57, 0x69, 0x64, 0x74,
+	0x68, 0x12, 0x39, 0x0a, 0x11, 0x77, 0x69, 0x64, 0x65, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64,
+	0x5f, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c,
+	0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0f, 0x77, 0x69, 0x64,
+	0x65, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x3b, 0x0a, 0x12,
+	0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x10, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x53,
+	0x68, 0x65, 0x6c, 0x6c, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x3d, 0x0a, 0x13, 0x6e, 0x61, 0x72,
+	0x72, 0x6f, 0x77, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x5f, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x11, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x4c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x57, 0x69, 0x64, 0x74, 0x68, 0x32, 0xd5, 0x05, 0x0a, 0x0a, 0x43, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x69,
+	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x6c, 0x7a,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x37,
+	0x0a, 0x06, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x12, 0x15, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x49, 0x6e, 0x69, 0x74, 0x53,
+	0x74, 0x65, 0x65, 0x6c, 0x12, 0x18, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e,
+	0x69, 0x74, 0x53, 0x74, 0x65, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12, 0x19, 0x2e,
+	0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x19, 0x2e, 0x6c,
+	0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x36, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x12, 0x17, 0x2e, 0x6c, 0x7a,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6c,
+	0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x65,
+	0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x31, 0x12, 0x19, 0x2e, 0x6c,
+	0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x31, 0x12,
+	0x4d, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c,
+	0x69, 0x63, 0x65, 0x32, 0x12, 0x20, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x32, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x32, 0x12, 0x40,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x75, 0x72, 0x76, 0x65, 0x73,
+	0x12, 0x19, 0x2e, 0x6c, 0x7a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6c, 0x7a,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x43, 0x75, 0x72, 0x76, 0x65, 0x73,
+	0x42, 0x08, 0x5a, 0x06, 0x6c, 0x7a, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+})
+
+var (
+	file_calculator_proto_rawDescOnce sync.Once
+	file_calculator_proto_rawDescData []byte
+)
+
+func file_calculator_proto_rawDescGZIP() []byte {
+	file_calculator_proto_rawDescOnce.Do(func() {
+		file_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_calculator_proto_rawDesc), len(file_calculator_proto_rawDesc)))
+	})
+	return file_calculator_proto_rawDescData
+}
+
+var file_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_calculator_proto_goTypes = []any{
+	(*SimulationRequest)(nil),            // 0: lz.rpc.SimulationRequest
+	(*Simulation)(nil),                   // 1: lz.rpc.Simulation
+	(*SetEnvRequest)(nil),                // 2: lz.rpc.SetEnvRequest
+	(*InitSteelRequest)(nil),             // 3: lz.rpc.InitSteelRequest
+	(*GetSliceRequest)(nil),              // 4: lz.rpc.GetSliceRequest
+	(*GetVerticalSlice2Request)(nil),     // 5: lz.rpc.GetVerticalSlice2Request
+	(*Env)(nil),                          // 6: lz.rpc.Env
+	(*Coordinate)(nil),                   // 7: lz.rpc.Coordinate
+	(*BendingZone)(nil),                  // 8: lz.rpc.BendingZone
+	(*Md)(nil),                           // 9: lz.rpc.Md
+	(*SecondaryCoolingWaterSection)(nil), // 10: lz.rpc.SecondaryCoolingWaterSection
+	(*CoolingZone)(nil),                  // 11: lz.rpc.CoolingZone
+	(*Row)(nil),                          // 12: lz.rpc.Row
+	(*Point)(nil),                        // 13: lz.rpc.Point
+	(*TemperatureField)(nil),             // 14: lz.rpc.TemperatureField
+	(*Sides)(nil),                        // 15: lz.rpc.Sides
+	(*WidthTransition)(nil),              // 16: lz.rpc.WidthTransition
+	(*GeometryPoint)(nil),                // 17: lz.rpc.GeometryPoint
+	(*SliceInfo)(nil),                    // 18: lz.rpc.SliceInfo
+	(*VerticalSlice1)(nil),               // 19: lz.rpc.VerticalSlice1
+	(*Join)(nil),                         // 20: lz.rpc.Join
+	(*VerticalSlice2)(nil),               // 21: lz.rpc.VerticalSlice2
+	(*ShellCurves)(nil),                  // 22: lz.rpc.ShellCurves
+	(*emptypb.Empty)(nil),                // 23: google.protobuf.Empty
+}
+var file_calculator_proto_depIdxs = []int32{
+	6,  // 0: lz.rpc.SetEnvRequest.env:type_name -> lz.rpc.Env
+	9,  // 1: lz.rpc.Env.md:type_name -> lz.rpc.Md
+	7,  // 2: lz.rpc.Env.coordinate:type_name -> lz.rpc.Coordinate
+	10, // 3: lz.rpc.Env.secondary_cooling_water_cfg:type_name -> lz.rpc.SecondaryCoolingWaterSection
+	11, // 4: lz.rpc.Env.cooling_zone_cfg:type_name -> lz.rpc.CoolingZone
+	8,  // 5: lz.rpc.Coordinate.bending_zones:type_name -> lz.rpc.BendingZone
+	15, // 6: lz.rpc.TemperatureField.sides:type_name -> lz.rpc.Sides
+	16, // 7: lz.rpc.TemperatureField.width_transition:type_name -> lz.rpc.WidthTransition
+	17, // 8: lz.rpc.TemperatureField.geometry:type_name -> lz.rpc.GeometryPoint
+	12, // 9: lz.rpc.Sides.up:type_name -> lz.rpc.Row
+	12, // 10: lz.rpc.Sides.left:type_name -> lz.rpc.Row
+	12, // 11: lz.rpc.Sides.right:type_name -> lz.rpc.Row
+	12, // 12: lz.rpc.Sides.front:type_name -> lz.rpc.Row
+	12, // 13: lz.rpc.Sides.back:type_name -> lz.rpc.Row
+	12, // 14: lz.rpc.Sides.down:type_name -> lz.rpc.Row
+	12, // 15: lz.rpc.SliceInfo.slice:type_name -> lz.rpc.Row
+	13, // 16: lz.rpc.VerticalSlice1.center_outer:type_name -> lz.rpc.Point
+	13, // 17: lz.rpc.VerticalSlice1.center_inner:type_name -> lz.rpc.Point
+	13, // 18: lz.rpc.VerticalSlice1.edge_outer:type_name -> lz.rpc.Point
+	13, // 19: lz.rpc.VerticalSlice1.edge_inner:type_name -> lz.rpc.Point
+	12, // 20: lz.rpc.VerticalSlice2.vertical_slice:type_name -> lz.rpc.Row
+	20, // 21: lz.rpc.VerticalSlice2.solid_join:type_name -> lz.rpc.Join
+	20, // 22: lz.rpc.VerticalSlice2.liquid_join:type_name -> lz.rpc.Join
+	13, // 23: lz.rpc.ShellCurves.wide_shell_width:type_name -> lz.rpc.Point
+	13, // 24: lz.rpc.ShellCurves.wide_liquid_width:type_name -> lz.rpc.Point
+	13, // 25: lz.rpc.ShellCurves.narrow_shell_width:type_name -> lz.rpc.Point
+	13, // 26: lz.rpc.ShellCurves.narrow_liquid_width:type_name -> lz.rpc.Point
+	23, // 27: lz.rpc.Calculator.CreateSimulation:input_type -> google.protobuf.Empty
+	0,  // 28: lz.rpc.Calculator.GetSimulation:input_type -> lz.rpc.SimulationRequest
+	0,  // 29: lz.rpc.Calculator.DeleteSimulation:input_type -> lz.rpc.SimulationRequest
+	2,  // 30: lz.rpc.Calculator.SetEnv:input_type -> lz.rpc.SetEnvRequest
+	3,  // 31: lz.rpc.Calculator.InitSteel:input_type -> lz.rpc.InitSteelRequest
+	0,  // 32: lz.rpc.Calculator.Run:input_type -> lz.rpc.SimulationRequest
+	0,  // 33: lz.rpc.Calculator.Stop:input_type -> lz.rpc.SimulationRequest
+	4,  // 34: lz.rpc.Calculator.GetSlice:input_type -> lz.rpc.GetSliceRequest
+	0,  // 35: lz.rpc.Calculator.GetVerticalSlice1:input_type -> lz.rpc.SimulationRequest
+	5,  // 36: lz.rpc.Calculator.GetVerticalSlice2:input_type -> lz.rpc.GetVerticalSlice2Request
+	0,  // 37: lz.rpc.Calculator.GetShellCurves:input_type -> lz.rpc.SimulationRequest
+	1,  // 38: lz.rpc.Calculator.CreateSimulation:output_type -> lz.rpc.Simulation
+	1,  // 39: lz.rpc.Calculator.GetSimulation:output_type -> lz.rpc.Simulation
+	23, // 40: lz.rpc.Calculator.DeleteSimulation:output_type -> google.protobuf.Empty
+	23, // 41: lz.rpc.Calculator.SetEnv:output_type -> google.protobuf.Empty
+	23, // 42: lz.rpc.Calculator.InitSteel:output_type -> google.protobuf.Empty
+	14, // 43: lz.rpc.Calculator.Run:output_type -> lz.rpc.TemperatureField
+	23, // 44: lz.rpc.Calculator.Stop:output_type -> google.protobuf.Empty
+	18, // 45: lz.rpc.Calculator.GetSlice:output_type -> lz.rpc.SliceInfo
+	19, // 46: lz.rpc.Calculator.GetVerticalSlice1:output_type -> lz.rpc.VerticalSlice1
+	21, // 47: lz.rpc.Calculator.GetVerticalSlice2:output_type -> lz.rpc.VerticalSlice2
+	22, // 48: lz.rpc.Calculator.GetShellCurves:output_type -> lz.rpc.ShellCurves
+	38, // [38:49] is the sub-list for method output_type
+	27, // [27:38] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_calculator_proto_init() }
+func file_calculator_proto_init() {
+	if File_calculator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calculator_proto_rawDesc), len(file_calculator_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_calculator_proto_goTypes,
+		DependencyIndexes: file_calculator_proto_depIdxs,
+		MessageInfos:      file_calculator_proto_msgTypes,
+	}.Build()
+	File_calculator_proto = out.File
+	file_calculator_proto_goTypes = nil
+	file_calculator_proto_depIdxs = nil
+}
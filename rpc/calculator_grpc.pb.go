@@ -0,0 +1,532 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: calculator.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Calculator_CreateSimulation_FullMethodName  = "/lz.rpc.Calculator/CreateSimulation"
+	Calculator_GetSimulation_FullMethodName     = "/lz.rpc.Calculator/GetSimulation"
+	Calculator_DeleteSimulation_FullMethodName  = "/lz.rpc.Calculator/DeleteSimulation"
+	Calculator_SetEnv_FullMethodName            = "/lz.rpc.Calculator/SetEnv"
+	Calculator_InitSteel_FullMethodName         = "/lz.rpc.Calculator/InitSteel"
+	Calculator_Run_FullMethodName               = "/lz.rpc.Calculator/Run"
+	Calculator_Stop_FullMethodName              = "/lz.rpc.Calculator/Stop"
+	Calculator_GetSlice_FullMethodName          = "/lz.rpc.Calculator/GetSlice"
+	Calculator_GetVerticalSlice1_FullMethodName = "/lz.rpc.Calculator/GetVerticalSlice1"
+	Calculator_GetVerticalSlice2_FullMethodName = "/lz.rpc.Calculator/GetVerticalSlice2"
+	Calculator_GetShellCurves_FullMethodName    = "/lz.rpc.Calculator/GetShellCurves"
+)
+
+// CalculatorClient is the client API for Calculator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 三维温度场计算服务，与 HTTP 接口共用仿真，长度单位 mm，温度单位 ℃
+type CalculatorClient interface {
+	// 新建仿真，之后需设置计算环境
+	CreateSimulation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Simulation, error)
+	// 仿真状态
+	GetSimulation(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*Simulation, error)
+	// 删除仿真，正在计算时先停止
+	DeleteSimulation(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 设置计算环境，校验规则与 websocket 相同
+	SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 设置钢种物性参数，只能在开始计算前设置
+	InitSteel(ctx context.Context, in *InitSteelRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 开始计算（已在计算时不重复开始）并周期性推送温度场，直到停止计算或客户端取消，客户端取消不停止计算
+	Run(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TemperatureField], error)
+	// 停止计算
+	Stop(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 横切面
+	GetSlice(ctx context.Context, in *GetSliceRequest, opts ...grpc.CallOption) (*SliceInfo, error)
+	// 纵切面曲线
+	GetVerticalSlice1(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*VerticalSlice1, error)
+	// 纵切面云图
+	GetVerticalSlice2(ctx context.Context, in *GetVerticalSlice2Request, opts ...grpc.CallOption) (*VerticalSlice2, error)
+	// 坯壳厚度变化曲线
+	GetShellCurves(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*ShellCurves, error)
+}
+
+type calculatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalculatorClient(cc grpc.ClientConnInterface) CalculatorClient {
+	return &calculatorClient{cc}
+}
+
+func (c *calculatorClient) CreateSimulation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Simulation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Simulation)
+	err := c.cc.Invoke(ctx, Calculator_CreateSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) GetSimulation(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*Simulation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Simulation)
+	err := c.cc.Invoke(ctx, Calculator_GetSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) DeleteSimulation(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calculator_DeleteSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calculator_SetEnv_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) InitSteel(ctx context.Context, in *InitSteelRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calculator_InitSteel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) Run(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TemperatureField], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Calculator_ServiceDesc.Streams[0], Calculator_Run_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SimulationRequest, TemperatureField]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calculator_RunClient = grpc.ServerStreamingClient[TemperatureField]
+
+func (c *calculatorClient) Stop(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calculator_Stop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) GetSlice(ctx context.Context, in *GetSliceRequest, opts ...grpc.CallOption) (*SliceInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SliceInfo)
+	err := c.cc.Invoke(ctx, Calculator_GetSlice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) GetVerticalSlice1(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*VerticalSlice1, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerticalSlice1)
+	err := c.cc.Invoke(ctx, Calculator_GetVerticalSlice1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) GetVerticalSlice2(ctx context.Context, in *GetVerticalSlice2Request, opts ...grpc.CallOption) (*VerticalSlice2, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerticalSlice2)
+	err := c.cc.Invoke(ctx, Calculator_GetVerticalSlice2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) GetShellCurves(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*ShellCurves, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShellCurves)
+	err := c.cc.Invoke(ctx, Calculator_GetShellCurves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalculatorServer is the server API for Calculator service.
+// All implementations must embed UnimplementedCalculatorServer
+// for forward compatibility.
+//
+// 三维温度场计算服务，与 HTTP 接口共用仿真，长度单位 mm，温度单位 ℃
+type CalculatorServer interface {
+	// 新建仿真，之后需设置计算环境
+	CreateSimulation(context.Context, *emptypb.Empty) (*Simulation, error)
+	// 仿真状态
+	GetSimulation(context.Context, *SimulationRequest) (*Simulation, error)
+	// 删除仿真，正在计算时先停止
+	DeleteSimulation(context.Context, *SimulationRequest) (*emptypb.Empty, error)
+	// 设置计算环境，校验规则与 websocket 相同
+	SetEnv(context.Context, *SetEnvRequest) (*emptypb.Empty, error)
+	// 设置钢种物性参数，只能在开始计算前设置
+	InitSteel(context.Context, *InitSteelRequest) (*emptypb.Empty, error)
+	// 开始计算（已在计算时不重复开始）并周期性推送温度场，直到停止计算或客户端取消，客户端取消不停止计算
+	Run(*SimulationRequest, grpc.ServerStreamingServer[TemperatureField]) error
+	// 停止计算
+	Stop(context.Context, *SimulationRequest) (*emptypb.Empty, error)
+	// 横切面
+	GetSlice(context.Context, *GetSliceRequest) (*SliceInfo, error)
+	// 纵切面曲线
+	GetVerticalSlice1(context.Context, *SimulationRequest) (*VerticalSlice1, error)
+	// 纵切面云图
+	GetVerticalSlice2(context.Context, *GetVerticalSlice2Request) (*VerticalSlice2, error)
+	// 坯壳厚度变化曲线
+	GetShellCurves(context.Context, *SimulationRequest) (*ShellCurves, error)
+	mustEmbedUnimplementedCalculatorServer()
+}
+
+// UnimplementedCalculatorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCalculatorServer struct{}
+
+func (UnimplementedCalculatorServer) CreateSimulation(context.Context, *emptypb.Empty) (*Simulation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSimulation not implemented")
+}
+func (UnimplementedCalculatorServer) GetSimulation(context.Context, *SimulationRequest) (*Simulation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimulation not implemented")
+}
+func (UnimplementedCalculatorServer) DeleteSimulation(context.Context, *SimulationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSimulation not implemented")
+}
+func (UnimplementedCalculatorServer) SetEnv(context.Context, *SetEnvRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEnv not implemented")
+}
+func (UnimplementedCalculatorServer) InitSteel(context.Context, *InitSteelRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitSteel not implemented")
+}
+func (UnimplementedCalculatorServer) Run(*SimulationRequest, grpc.ServerStreamingServer[TemperatureField]) error {
+	return status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+func (UnimplementedCalculatorServer) Stop(context.Context, *SimulationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedCalculatorServer) GetSlice(context.Context, *GetSliceRequest) (*SliceInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlice not implemented")
+}
+func (UnimplementedCalculatorServer) GetVerticalSlice1(context.Context, *SimulationRequest) (*VerticalSlice1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVerticalSlice1 not implemented")
+}
+func (UnimplementedCalculatorServer) GetVerticalSlice2(context.Context, *GetVerticalSlice2Request) (*VerticalSlice2, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVerticalSlice2 not implemented")
+}
+func (UnimplementedCalculatorServer) GetShellCurves(context.Context, *SimulationRequest) (*ShellCurves, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShellCurves not implemented")
+}
+func (UnimplementedCalculatorServer) mustEmbedUnimplementedCalculatorServer() {}
+func (UnimplementedCalculatorServer) testEmbeddedByValue()                    {}
+
+// UnsafeCalculatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalculatorServer will
+// result in compilation errors.
+type UnsafeCalculatorServer interface {
+	mustEmbedUnimplementedCalculatorServer()
+}
+
+func RegisterCalculatorServer(s grpc.ServiceRegistrar, srv CalculatorServer) {
+	// If the following call pancis, it indicates UnimplementedCalculatorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Calculator_ServiceDesc, srv)
+}
+
+func _Calculator_CreateSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).CreateSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_CreateSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).CreateSimulation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_GetSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).GetSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_GetSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).GetSimulation(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_DeleteSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).DeleteSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_DeleteSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).DeleteSimulation(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_SetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).SetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_SetEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).SetEnv(ctx, req.(*SetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_InitSteel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitSteelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).InitSteel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_InitSteel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).InitSteel(ctx, req.(*InitSteelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_Run_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SimulationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServer).Run(m, &grpc.GenericServerStream[SimulationRequest, TemperatureField]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calculator_RunServer = grpc.ServerStreamingServer[TemperatureField]
+
+func _Calculator_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_Stop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).Stop(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_GetSlice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSliceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).GetSlice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_GetSlice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).GetSlice(ctx, req.(*GetSliceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_GetVerticalSlice1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).GetVerticalSlice1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_GetVerticalSlice1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).GetVerticalSlice1(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_GetVerticalSlice2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVerticalSlice2Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).GetVerticalSlice2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_GetVerticalSlice2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).GetVerticalSlice2(ctx, req.(*GetVerticalSlice2Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_GetShellCurves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).GetShellCurves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_GetShellCurves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).GetShellCurves(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Calculator_ServiceDesc is the grpc.ServiceDesc for Calculator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Calculator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lz.rpc.Calculator",
+	HandlerType: (*CalculatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSimulation",
+			Handler:    _Calculator_CreateSimulation_Handler,
+		},
+		{
+			MethodName: "GetSimulation",
+			Handler:    _Calculator_GetSimulation_Handler,
+		},
+		{
+			MethodName: "DeleteSimulation",
+			Handler:    _Calculator_DeleteSimulation_Handler,
+		},
+		{
+			MethodName: "SetEnv",
+			Handler:    _Calculator_SetEnv_Handler,
+		},
+		{
+			MethodName: "InitSteel",
+			Handler:    _Calculator_InitSteel_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _Calculator_Stop_Handler,
+		},
+		{
+			MethodName: "GetSlice",
+			Handler:    _Calculator_GetSlice_Handler,
+		},
+		{
+			MethodName: "GetVerticalSlice1",
+			Handler:    _Calculator_GetVerticalSlice1_Handler,
+		},
+		{
+			MethodName: "GetVerticalSlice2",
+			Handler:    _Calculator_GetVerticalSlice2_Handler,
+		},
+		{
+			MethodName: "GetShellCurves",
+			Handler:    _Calculator_GetShellCurves_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Run",
+			Handler:       _Calculator_Run_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "calculator.proto",
+}
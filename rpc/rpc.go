@@ -0,0 +1,4 @@
+// rpc 为三维温度场计算的 gRPC 接口定义，修改 calculator.proto 后重新生成代码
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative calculator.proto
@@ -0,0 +1,410 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	log "github.com/sirupsen/logrus"
+	"lz/calculator"
+	"lz/model"
+	"lz/validation"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	errSimulationNotFound = errors.New("仿真不存在")
+	errEnvNotSet          = errors.New("计算环境未设置")
+	errRunning            = errors.New("仿真正在计算")
+	errNotRunning         = errors.New("仿真没有在计算")
+	errSliceOutOfRange    = errors.New("切片下标越界")
+)
+
+// HTTP 和 gRPC 接口中的一个仿真，与一个 websocket 连接的计算环境相同
+type simulation struct {
+	id          string
+	mu          sync.Mutex
+	c           calculator.Calculator
+	selected    *model.Caster
+	running     bool
+	subscribers map[chan struct{}]bool // 本次计算的温度场推送订阅者，计算停止时关闭
+}
+
+// 开始计算，需持有 sim.mu
+func (sim *simulation) start() error {
+	if sim.c == nil {
+		return errEnvNotSet
+	}
+	if sim.running {
+		return errRunning
+	}
+	calcHub := sim.c.GetCalcHub()
+	calcHub.StartSignal()
+	sim.subscribers = make(map[chan struct{}]bool)
+	go sim.c.Run()
+	go sim.broadcast(calcHub, sim.subscribers)
+	sim.running = true
+	log.WithField("id", sim.id).Info("开始计算三维温度场")
+	return nil
+}
+
+// 停止计算，需持有 sim.mu
+func (sim *simulation) stop() error {
+	if !sim.running {
+		return errNotRunning
+	}
+	sim.c.GetCalcHub().StopSignal()
+	sim.running = false
+	log.WithField("id", sim.id).Info("停止计算三维温度场")
+	return nil
+}
+
+// 订阅本次计算的温度场推送，需持有 sim.mu 且正在计算。返回的通道在计算停止时关闭
+func (sim *simulation) subscribe() (<-chan struct{}, func()) {
+	subscribers := sim.subscribers
+	ch := make(chan struct{}, 1)
+	subscribers[ch] = true
+	return ch, func() {
+		sim.mu.Lock()
+		delete(subscribers, ch)
+		sim.mu.Unlock()
+	}
+}
+
+// 取走计算结果通知并转发给订阅者，避免计算阻塞。订阅者来不及处理时丢弃本次通知
+func (sim *simulation) broadcast(calcHub *calculator.CalcHub, subscribers map[chan struct{}]bool) {
+	stop := calcHub.Stop
+	for {
+		select {
+		case <-stop:
+			sim.mu.Lock()
+			for ch := range subscribers {
+				close(ch)
+				delete(subscribers, ch)
+			}
+			sim.mu.Unlock()
+			return
+		case <-calcHub.PeriodCalcResult:
+			sim.mu.Lock()
+			for ch := range subscribers {
+				select {
+				case ch <- struct{}{}:
+				default:
+				}
+			}
+			sim.mu.Unlock()
+		}
+	}
+}
+
+// 仿真状态
+type simulationStatus struct {
+	ID               string                 `json:"id"`
+	Caster           string                 `json:"caster"`
+	EnvSet           bool                   `json:"env_set"`
+	Running          bool                   `json:"running"`
+	FieldSize        int                    `json:"field_size"` // 已生成的切片数
+	V                float32                `json:"v"`          // 拉速 m/min
+	StartTemperature float32                `json:"start_temperature"`
+	CoolingMode      string                 `json:"cooling_mode"`
+	Indicators       *calculator.Indicators `json:"indicators,omitempty"`
+}
+
+// HTTP/JSON 接口，与 websocket 协议的功能对应，校验错误的响应内容与 websocket 相同
+func (s *Server) apiHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/simulations", s.createSimulation)
+	mux.HandleFunc("GET /api/simulations/{id}", s.withSimulation(getStatus))
+	mux.HandleFunc("DELETE /api/simulations/{id}", s.deleteSimulation)
+	mux.HandleFunc("PUT /api/simulations/{id}/env", s.withSimulation(s.setEnv))
+	mux.HandleFunc("PUT /api/simulations/{id}/v", s.withSimulation(setV))
+	mux.HandleFunc("PUT /api/simulations/{id}/initial_temp", s.withSimulation(setInitialTemp))
+	mux.HandleFunc("PUT /api/simulations/{id}/md", s.withSimulation(setMd))
+	mux.HandleFunc("PUT /api/simulations/{id}/narrow_surface", s.withSimulation(setNarrowSurface))
+	mux.HandleFunc("PUT /api/simulations/{id}/wide_surface", s.withSimulation(setWideSurface))
+	mux.HandleFunc("PUT /api/simulations/{id}/width", s.withSimulation(changeWidth))
+	mux.HandleFunc("POST /api/simulations/{id}/start", s.withSimulation(start))
+	mux.HandleFunc("POST /api/simulations/{id}/stop", s.withSimulation(stop))
+	mux.HandleFunc("GET /api/simulations/{id}/slices/{index}", s.withSimulation(getSlice))
+	mux.HandleFunc("GET /api/simulations/{id}/shell_curves", s.withSimulation(getShellCurves))
+	return mux
+}
+
+// 新建仿真，之后需设置计算环境
+func (s *Server) createSimulation(w http.ResponseWriter, r *http.Request) {
+	sim := s.newSimulation()
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	writeJSON(w, http.StatusCreated, getStatusLocked(sim))
+}
+
+// 删除仿真，正在计算时先停止，并释放计算器
+func (s *Server) deleteSimulation(w http.ResponseWriter, r *http.Request) {
+	if err := s.removeSimulation(r.PathValue("id")); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 查找路径中的仿真并在持有仿真锁时调用 handle
+func (s *Server) withSimulation(handle func(w http.ResponseWriter, r *http.Request, sim *simulation)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sim, err := s.simulation(r.PathValue("id"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		handle(w, r, sim)
+	}
+}
+
+func (s *Server) newSimulation() *simulation {
+	s.simMu.Lock()
+	defer s.simMu.Unlock()
+	s.nextSimulation++
+	sim := &simulation{id: strconv.Itoa(s.nextSimulation)}
+	s.simulations[sim.id] = sim
+	log.WithField("id", sim.id).Info("新建仿真")
+	return sim
+}
+
+func (s *Server) removeSimulation(id string) error {
+	s.simMu.Lock()
+	sim, ok := s.simulations[id]
+	delete(s.simulations, id)
+	s.simMu.Unlock()
+	if !ok {
+		return errSimulationNotFound
+	}
+	sim.mu.Lock()
+	if sim.running {
+		sim.stop()
+	}
+	c := sim.c
+	sim.c = nil
+	sim.mu.Unlock()
+	// 等待计算协程结束后释放温度场，不持有仿真锁，避免阻塞推送
+	if c != nil {
+		c.Close()
+	}
+	log.WithField("id", id).Info("删除仿真")
+	return nil
+}
+
+func (s *Server) simulation(id string) (*simulation, error) {
+	s.simMu.Lock()
+	defer s.simMu.Unlock()
+	sim, ok := s.simulations[id]
+	if !ok {
+		return nil, errSimulationNotFound
+	}
+	return sim, nil
+}
+
+func getStatus(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	writeJSON(w, http.StatusOK, getStatusLocked(sim))
+}
+
+func getStatusLocked(sim *simulation) *simulationStatus {
+	status := &simulationStatus{ID: sim.id, Running: sim.running}
+	if sim.selected != nil {
+		status.Caster = sim.selected.Name
+	}
+	if sim.c == nil {
+		return status
+	}
+	cm := sim.c.GetCastingMachine()
+	status.EnvSet = true
+	status.FieldSize = sim.c.GetFieldSize()
+	status.V = float32(cm.CoolerConfig.V) * 60 / 1000
+	status.StartTemperature = cm.CoolerConfig.StartTemperature
+	status.CoolingMode = sim.c.GetCoolingMode()
+	status.Indicators = sim.c.GenerateIndicators()
+	return status
+}
+
+func (s *Server) setEnv(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	var env model.Env
+	if !decode(w, r, &env) {
+		return
+	}
+	c, selected, err := applyEnv(s.casters, sim.selected, sim.c, env)
+	if err != nil {
+		log.WithField("err", err).Error("设置计算环境失败")
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	sim.c, sim.selected = c, selected
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 修改拉速，停止播放拉速曲线
+func setV(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	var v float32
+	if !decode(w, r, &v) || !envSet(w, sim) {
+		return
+	}
+	if errs := validation.Signal("v", model.SignalSpeed, v, 0); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, errs)
+		return
+	}
+	sim.c.GetCastingMachine().StopSpeedSchedule()
+	sim.c.GetCastingMachine().SetV(v)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 修改浇铸温度，停止中间包温度模型
+func setInitialTemp(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	var temp float32
+	if !decode(w, r, &temp) || !envSet(w, sim) {
+		return
+	}
+	if errs := validation.Signal("initial_temp", model.SignalTundishTemperature, temp, 0); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, errs)
+		return
+	}
+	sim.c.GetCastingMachine().StopTundish()
+	sim.c.GetCastingMachine().SetStartTemperature(temp)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setMd(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	var md model.Md
+	if !decode(w, r, &md) || !envSet(w, sim) {
+		return
+	}
+	if errs := validation.Md("md", md); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, errs)
+		return
+	}
+	sim.c.GetCastingMachine().SetMd(md)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setNarrowSurface(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	var narrowSurface model.NarrowSurface
+	if !decode(w, r, &narrowSurface) || !envSet(w, sim) {
+		return
+	}
+	if errs := validation.MdSurface("narrow_surface", narrowSurface.In, narrowSurface.Out, narrowSurface.Volume); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, errs)
+		return
+	}
+	cm := sim.c.GetCastingMachine()
+	cm.SetNarrowSurfaceIn(narrowSurface.In)
+	cm.SetNarrowSurfaceOut(narrowSurface.Out)
+	if narrowSurface.Volume > 0 {
+		cm.SetNarrowWaterVolume(narrowSurface.Volume)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setWideSurface(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	var wideSurface model.WideSurface
+	if !decode(w, r, &wideSurface) || !envSet(w, sim) {
+		return
+	}
+	if errs := validation.MdSurface("wide_surface", wideSurface.In, wideSurface.Out, wideSurface.Volume); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, errs)
+		return
+	}
+	cm := sim.c.GetCastingMachine()
+	cm.SetWideSurfaceIn(wideSurface.In)
+	cm.SetWideSurfaceOut(wideSurface.Out)
+	if wideSurface.Volume > 0 {
+		cm.SetWideWaterVolume(wideSurface.Volume)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 在线调宽
+func changeWidth(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	var widthChange model.WidthChange
+	if !decode(w, r, &widthChange) || !envSet(w, sim) {
+		return
+	}
+	// 温度场网格按设置计算环境时的宽度划分，调宽后的宽度不能超过该宽度
+	if errs := validation.WidthChange("width_change", widthChange, calculator.Length*2); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, errs)
+		return
+	}
+	sim.c.GetCastingMachine().StartWidthChange(widthChange.Width, time.Duration(widthChange.Duration*float32(time.Second)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 开始计算，计算结果通过状态和切片等接口查询
+func start(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	if err := sim.start(); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func stop(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	if err := sim.stop(); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 横切面信息
+func getSlice(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	if !envSet(w, sim) {
+		return
+	}
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if index < 0 || index >= sim.c.GetFieldSize() {
+		writeError(w, http.StatusNotFound, errSliceOutOfRange)
+		return
+	}
+	writeJSON(w, http.StatusOK, sim.c.GenerateSLiceInfo(index))
+}
+
+// 坯壳厚度变化数据
+func getShellCurves(w http.ResponseWriter, r *http.Request, sim *simulation) {
+	if !envSet(w, sim) {
+		return
+	}
+	writeJSON(w, http.StatusOK, sim.c.GenerateShellCurves())
+}
+
+// 计算环境未设置时返回 409
+func envSet(w http.ResponseWriter, sim *simulation) bool {
+	if sim.c == nil {
+		writeError(w, http.StatusConflict, errEnvNotSet)
+		return false
+	}
+	return true
+}
+
+// 解析请求内容，失败时返回 400
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithField("err", err).Error("HTTP 响应json解析失败")
+	}
+}
+
+// 错误响应内容为校验错误列表
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, validation.From(err))
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"encoding/json"
+	"lz/calculator"
+	"lz/caster"
+	"lz/conf"
+	"lz/validation"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestApi(t *testing.T) *httptest.Server {
+	s := &Server{
+		casters:     caster.NewRepository(t.TempDir()),
+		simulations: make(map[string]*simulation),
+	}
+	ts := httptest.NewServer(s.apiHandler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func request(t *testing.T, method, url, body string) (*http.Response, validation.Errors) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var errs validation.Errors
+	if resp.StatusCode >= 400 && resp.Header.Get("Content-Type") == "application/json" {
+		if err = json.NewDecoder(resp.Body).Decode(&errs); err != nil {
+			t.Fatal("错误响应不是校验错误列表:", err)
+		}
+	}
+	return resp, errs
+}
+
+func TestApi(t *testing.T) {
+	ts := newTestApi(t)
+	resp, err := http.Post(ts.URL+"/api/simulations", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status simulationStatus
+	json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || status.ID == "" || status.EnvSet {
+		t.Fatalf("新建仿真失败: %d %+v", resp.StatusCode, status)
+	}
+	sim := ts.URL + "/api/simulations/" + status.ID
+
+	tests := []struct {
+		method, url, body string
+		code              int
+	}{
+		{http.MethodGet, sim, "", http.StatusOK},
+		{http.MethodGet, ts.URL + "/api/simulations/404", "", http.StatusNotFound},
+		{http.MethodPost, sim + "/start", "", http.StatusConflict},
+		{http.MethodPost, sim + "/stop", "", http.StatusConflict},
+		{http.MethodPut, sim + "/v", "1.2", http.StatusConflict},
+		{http.MethodPut, sim + "/v", "fast", http.StatusBadRequest},
+		{http.MethodGet, sim + "/slices/0", "", http.StatusConflict},
+		{http.MethodPut, sim + "/env", `{"caster": "missing"}`, http.StatusBadRequest},
+		{http.MethodGet, sim + "/env", "", http.StatusMethodNotAllowed},
+		{http.MethodDelete, sim, "", http.StatusNoContent},
+		{http.MethodGet, sim, "", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		resp, errs := request(t, test.method, test.url, test.body)
+		if resp.StatusCode != test.code {
+			t.Fatalf("%s %s 状态码应为 %d: %d %v", test.method, test.url, test.code, resp.StatusCode, errs)
+		}
+		if resp.StatusCode >= 400 && resp.StatusCode != http.StatusMethodNotAllowed && len(errs) == 0 {
+			t.Fatalf("%s %s 应返回错误信息", test.method, test.url)
+		}
+	}
+}
+
+func TestSimulationBroadcast(t *testing.T) {
+	calcHub := calculator.NewCalcHub()
+	calcHub.StartSignal()
+	sim := &simulation{subscribers: make(map[chan struct{}]bool)}
+	go sim.broadcast(calcHub, sim.subscribers)
+	sim.mu.Lock()
+	pushed, unsubscribe := sim.subscribe()
+	idle, _ := sim.subscribe()
+	sim.mu.Unlock()
+
+	// 没有及时处理的订阅者丢弃通知，不阻塞计算
+	calcHub.PushSignal()
+	calcHub.PushSignal()
+	if _, ok := <-pushed; !ok {
+		t.Fatal("应收到温度场推送通知")
+	}
+	unsubscribe()
+	calcHub.StopSignal()
+	for range idle {
+	}
+	select {
+	case <-pushed:
+		t.Fatal("取消订阅后不应收到通知")
+	default:
+	}
+}
+
+func TestApiSimulation(t *testing.T) {
+	s := &Server{
+		casters:     caster.NewRepository(conf.AppConfig.CasterHomePath),
+		simulations: make(map[string]*simulation),
+	}
+	ts := httptest.NewServer(s.apiHandler())
+	defer ts.Close()
+	resp, err := http.Post(ts.URL+"/api/simulations", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status simulationStatus
+	json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	sim := ts.URL + "/api/simulations/" + status.ID
+	// 连接协程不计入，比较前关闭空闲连接
+	http.DefaultClient.CloseIdleConnections()
+	time.Sleep(100 * time.Millisecond)
+	before := runtime.NumGoroutine()
+
+	env, _ := json.Marshal(testEnv(t))
+	for _, step := range []struct{ method, url, body string }{
+		{http.MethodPut, sim + "/env", string(env)},
+		{http.MethodPut, sim + "/v", "1.2"},
+		{http.MethodPost, sim + "/start", ""},
+	} {
+		if resp, errs := request(t, step.method, step.url, step.body); resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("%s %s 失败: %d %v", step.method, step.url, resp.StatusCode, errs)
+		}
+	}
+	// 等待产生切片
+	deadline := time.Now().Add(10 * time.Second)
+	for status.FieldSize == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		resp, err = http.Get(sim)
+		if err != nil {
+			t.Fatal(err)
+		}
+		json.NewDecoder(resp.Body).Decode(&status)
+		resp.Body.Close()
+	}
+	if !status.EnvSet || !status.Running || status.Caster != "caster" || status.V != 1.2 || status.FieldSize == 0 {
+		t.Fatalf("仿真状态不正确: %+v", status)
+	}
+	resp, err = http.Get(sim + "/slices/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slice calculator.SliceInfo
+	err = json.NewDecoder(resp.Body).Decode(&slice)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || err != nil || len(slice.Slice) == 0 {
+		t.Fatalf("获取切片失败: %d %v", resp.StatusCode, err)
+	}
+	if resp, errs := request(t, http.MethodGet, sim+"/shell_curves", ""); resp.StatusCode != http.StatusOK {
+		t.Fatalf("获取坯壳厚度失败: %d %v", resp.StatusCode, errs)
+	}
+
+	// 已有计算器时不能使用不同的铸坯断面
+	resp, err = http.Post(ts.URL+"/api/simulations", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var other simulationStatus
+	json.NewDecoder(resp.Body).Decode(&other)
+	resp.Body.Close()
+	narrow := testEnv(t)
+	narrow.Coordinate.Width -= 20
+	body, _ := json.Marshal(narrow)
+	resp, errs := request(t, http.MethodPut, ts.URL+"/api/simulations/"+other.ID+"/env", string(body))
+	if resp.StatusCode != http.StatusBadRequest || len(errs) != 1 || errs[0].Field != "coordinate" {
+		t.Fatalf("铸坯断面不一致时应返回校验错误: %d %v", resp.StatusCode, errs)
+	}
+	request(t, http.MethodDelete, ts.URL+"/api/simulations/"+other.ID, "")
+
+	// 删除后释放计算协程
+	if resp, errs := request(t, http.MethodDelete, sim, ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("删除仿真失败: %d %v", resp.StatusCode, errs)
+	}
+	http.DefaultClient.CloseIdleConnections()
+	deadline = time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("删除仿真后计算协程没有退出: %d > %d", n, before)
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"lz/calculator"
+	"lz/caster"
+	"lz/conf"
+	"lz/model"
+	"lz/validation"
+)
+
+// 校验并设置计算环境，websocket 和 HTTP 接口共用。c 为 nil 时新建计算器
+// selected 为已选择的铸机，env 指定铸机时使用 env 中的铸机，返回设置后的计算器和铸机
+// 出错时不修改计算器，返回原来的计算器和铸机
+func applyEnv(casters *caster.Repository, selected *model.Caster, c calculator.Calculator, env model.Env) (calculator.Calculator, *model.Caster, error) {
+	target := selected
+	if env.Caster != "" {
+		got, err := casters.Get(env.Caster)
+		if err != nil {
+			return c, selected, err
+		}
+		target = got
+	}
+	nozzleCfg, err := loadNozzle(casters, target)
+	if err != nil {
+		return c, selected, err
+	}
+	data, err := json.Marshal(nozzleCfg)
+	if err != nil {
+		return c, selected, err
+	}
+	// 校验通过后再修改计算环境，避免参数只设置了一半
+	errs := validation.Env(env, *nozzleCfg)
+	if target != nil {
+		errs = append(errs, validation.Caster(target)...)
+		errs = append(errs, validation.CasterNozzle(target, *nozzleCfg)...)
+	}
+	if len(errs) > 0 {
+		log.WithField("errs", errs).Warn("计算环境参数校验失败")
+		return c, selected, errs
+	}
+	// 会出错的步骤先在副本上执行一遍，全部成功后再修改计算器
+	if err = configureCastingMachine(calculator.NewCastingMachine(), target, env, data); err != nil {
+		return c, selected, err
+	}
+	// 铸坯尺寸由所有计算器共用，与已有计算器不一致时不能修改
+	zLength, length, width := env.Coordinate.ZLength, env.Coordinate.Length/2, env.Coordinate.Width/2
+	if c == nil {
+		created, err := calculator.NewCalculatorWithGrid(zLength, length, width)
+		if err != nil {
+			return nil, selected, validation.Errors{{Field: "coordinate", Message: err.Error()}}
+		}
+		c = created
+	} else if err = calculator.CheckGrid(zLength, length, width); err != nil {
+		return c, selected, validation.Errors{{Field: "coordinate", Message: err.Error()}}
+	}
+	configureCastingMachine(c.GetCastingMachine(), target, env, data) // 副本上已经成功，不会出错
+	c.GetCastingMachine().SetV(env.DragSpeed)                         // 设置拉速
+	c.InitSteel(env.SteelValue, c.GetCastingMachine())                // 设置钢种物性参数
+	c.InitPushData(env.Coordinate)                                    // 设置推送数据相关参数
+	return c, target, nil
+}
+
+// 设置铸机尺寸、扇形段和冷却参数
+func configureCastingMachine(m *calculator.CastingMachine, selected *model.Caster, env model.Env, nozzleCfgData []byte) error {
+	m.SetFromJson(env.Coordinate) // 初始化铸机尺寸
+	if selected != nil {
+		// 设置扇形段
+		if err := m.SetSegments(selected.Segments, selected.SecondaryCoolingZone); err != nil {
+			return err
+		}
+	}
+	return m.SetCoolerConfig(env, nozzleCfgData) // 设置冷却参数
+}
+
+// 读取铸机的喷嘴布置，铸机未配置时使用全局喷嘴配置
+func loadNozzle(casters *caster.Repository, c *model.Caster) (*model.NozzleCfg, error) {
+	if c != nil {
+		nozzleCfg, err := casters.Nozzle(c)
+		if err != caster.ErrNoNozzleCfg {
+			return nozzleCfg, err
+		}
+		log.WithField("caster", c.Name).Warn("铸机未配置喷嘴布置，使用全局喷嘴配置")
+	}
+	data, err := ioutil.ReadFile(conf.AppConfig.NozzleConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	var nozzleCfg model.NozzleCfg
+	if err = json.Unmarshal(data, &nozzleCfg); err != nil {
+		return nil, err
+	}
+	return &nozzleCfg, nil
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"lz/caster"
+	"lz/conf"
+	"testing"
+)
+
+func TestApplyEnvFailure(t *testing.T) {
+	casters := caster.NewRepository(conf.AppConfig.CasterHomePath)
+	env := testEnv(t)
+	c, selected, err := applyEnv(casters, nil, nil, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if selected == nil || selected.Name != "caster" {
+		t.Fatalf("应选择 env 中的铸机: %v", selected)
+	}
+
+	// 出错时不修改计算器，也不修改已选择的铸机
+	changed := env
+	changed.Coordinate.Width -= 20
+	changed.StartTemperature = 1520
+	got, selected, err := applyEnv(casters, nil, c, changed)
+	if err == nil {
+		t.Fatal("铸坯断面与计算器不一致时应返回错误")
+	}
+	if got != c || selected != nil {
+		t.Fatal("出错时应返回原来的计算器和铸机")
+	}
+	cm := c.GetCastingMachine()
+	if cm.Coordinate.Width != env.Coordinate.Width || cm.CoolerConfig.StartTemperature != 1530 {
+		t.Fatalf("出错时不应修改计算环境: %d %v", cm.Coordinate.Width, cm.CoolerConfig.StartTemperature)
+	}
+}
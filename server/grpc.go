@@ -0,0 +1,398 @@
+package server
+
+import (
+	"context"
+	"errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"lz/calculator"
+	"lz/model"
+	"lz/rpc"
+	"lz/validation"
+	"net"
+	"time"
+)
+
+var errInvalidZScale = errors.New("纵切面缩放比例必须大于 0")
+
+// gRPC 接口，与 HTTP 接口共用仿真
+type grpcService struct {
+	rpc.UnimplementedCalculatorServer
+	s *Server
+}
+
+// 监听 gRPC 端口
+func (s *Server) serveGrpc(addr string) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.WithField("err", err).Fatal("gRPC 端口监听失败")
+	}
+	log.WithField("addr", addr).Info("gRPC 服务启动")
+	if err = s.newGrpcServer().Serve(lis); err != nil {
+		log.WithField("err", err).Fatal("gRPC 服务退出")
+	}
+}
+
+func (s *Server) newGrpcServer() *grpc.Server {
+	gs := grpc.NewServer()
+	rpc.RegisterCalculatorServer(gs, &grpcService{s: s})
+	return gs
+}
+
+func (g *grpcService) CreateSimulation(ctx context.Context, _ *emptypb.Empty) (*rpc.Simulation, error) {
+	sim := g.s.newSimulation()
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	return toSimulation(getStatusLocked(sim)), nil
+}
+
+func (g *grpcService) GetSimulation(ctx context.Context, req *rpc.SimulationRequest) (*rpc.Simulation, error) {
+	var res *rpc.Simulation
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		res = toSimulation(getStatusLocked(sim))
+		return nil
+	})
+	return res, err
+}
+
+func (g *grpcService) DeleteSimulation(ctx context.Context, req *rpc.SimulationRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, grpcError(g.s.removeSimulation(req.SimulationId))
+}
+
+func (g *grpcService) SetEnv(ctx context.Context, req *rpc.SetEnvRequest) (*emptypb.Empty, error) {
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		c, selected, err := applyEnv(g.s.casters, sim.selected, sim.c, toEnv(req.Env))
+		if err != nil {
+			log.WithField("err", err).Error("设置计算环境失败")
+			return invalidArgument(err)
+		}
+		sim.c, sim.selected = c, selected
+		return nil
+	})
+	return &emptypb.Empty{}, err
+}
+
+func (g *grpcService) InitSteel(ctx context.Context, req *rpc.InitSteelRequest) (*emptypb.Empty, error) {
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		if sim.c == nil {
+			return errEnvNotSet
+		}
+		if sim.running {
+			return errRunning
+		}
+		sim.c.InitSteel(int(req.SteelValue), sim.c.GetCastingMachine())
+		return nil
+	})
+	return &emptypb.Empty{}, err
+}
+
+func (g *grpcService) Run(req *rpc.SimulationRequest, stream rpc.Calculator_RunServer) error {
+	sim, err := g.s.simulation(req.SimulationId)
+	if err != nil {
+		return grpcError(err)
+	}
+	sim.mu.Lock()
+	if !sim.running {
+		if err = sim.start(); err != nil {
+			sim.mu.Unlock()
+			return grpcError(err)
+		}
+	}
+	pushed, unsubscribe := sim.subscribe()
+	sim.mu.Unlock()
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case _, ok := <-pushed:
+			if !ok {
+				// 计算已停止
+				return nil
+			}
+			start := time.Now()
+			sim.mu.Lock()
+			if sim.c == nil {
+				// 仿真已删除
+				sim.mu.Unlock()
+				return nil
+			}
+			field := toTemperatureField(sim.c.BuildData())
+			sim.mu.Unlock()
+			if err = stream.Send(field); err != nil {
+				return err
+			}
+			observePush("grpc", start)
+		}
+	}
+}
+
+func (g *grpcService) Stop(ctx context.Context, req *rpc.SimulationRequest) (*emptypb.Empty, error) {
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		return sim.stop()
+	})
+	return &emptypb.Empty{}, err
+}
+
+func (g *grpcService) GetSlice(ctx context.Context, req *rpc.GetSliceRequest) (*rpc.SliceInfo, error) {
+	var res *rpc.SliceInfo
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		if sim.c == nil {
+			return errEnvNotSet
+		}
+		if req.Index < 0 || int(req.Index) >= sim.c.GetFieldSize() {
+			return errSliceOutOfRange
+		}
+		info := sim.c.GenerateSLiceInfo(int(req.Index))
+		res = &rpc.SliceInfo{
+			HorizontalSolidThickness:  info.HorizontalSolidThickness,
+			VerticalSolidThickness:    info.VerticalSolidThickness,
+			HorizontalLiquidThickness: info.HorizontalLiquidThickness,
+			VerticalLiquidThickness:   info.VerticalLiquidThickness,
+			Slice:                     toRows(info.Slice),
+			Length:                    int32(info.Length),
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (g *grpcService) GetVerticalSlice1(ctx context.Context, req *rpc.SimulationRequest) (*rpc.VerticalSlice1, error) {
+	var res *rpc.VerticalSlice1
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		if sim.c == nil {
+			return errEnvNotSet
+		}
+		data := sim.c.GenerateVerticalSlice1Data()
+		res = &rpc.VerticalSlice1{
+			CenterOuter: toPoints(data.CenterOuter),
+			CenterInner: toPoints(data.CenterInner),
+			EdgeOuter:   toPoints(data.EdgeOuter),
+			EdgeInner:   toPoints(data.EdgeInner),
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (g *grpcService) GetVerticalSlice2(ctx context.Context, req *rpc.GetVerticalSlice2Request) (*rpc.VerticalSlice2, error) {
+	var res *rpc.VerticalSlice2
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		if sim.c == nil {
+			return errEnvNotSet
+		}
+		if req.Index < 0 || int(req.Index) >= calculator.Length/calculator.XStep {
+			return errSliceOutOfRange
+		}
+		if req.ZScale <= 0 {
+			return status.Error(codes.InvalidArgument, errInvalidZScale.Error())
+		}
+		data := sim.c.GenerateVerticalSlice2Data(model.VerticalReqData{Index: int(req.Index), ZScale: int(req.ZScale)})
+		res = &rpc.VerticalSlice2{
+			Length:        int32(data.Length),
+			VerticalSlice: toRows(data.VerticalSlice),
+			Solid:         data.Solid,
+			Liquid:        data.Liquid,
+			SolidJoin:     &rpc.Join{IsJoin: data.SolidJoin.IsJoin, JoinIndex: int32(data.SolidJoin.JoinIndex)},
+			LiquidJoin:    &rpc.Join{IsJoin: data.LiquidJoin.IsJoin, JoinIndex: int32(data.LiquidJoin.JoinIndex)},
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (g *grpcService) GetShellCurves(ctx context.Context, req *rpc.SimulationRequest) (*rpc.ShellCurves, error) {
+	var res *rpc.ShellCurves
+	err := g.withSimulation(req.SimulationId, func(sim *simulation) error {
+		if sim.c == nil {
+			return errEnvNotSet
+		}
+		data := sim.c.GenerateShellCurves()
+		res = &rpc.ShellCurves{
+			WideShellWidth:    toPoints(data.WideShellWidth),
+			WideLiquidWidth:   toPoints(data.WideLiquidWidth),
+			NarrowShellWidth:  toPoints(data.NarrowShellWidth),
+			NarrowLiquidWidth: toPoints(data.NarrowLiquidWidth),
+		}
+		return nil
+	})
+	return res, err
+}
+
+// 查找仿真并在持有仿真锁时调用 handle，返回 gRPC 状态错误
+func (g *grpcService) withSimulation(id string, handle func(sim *simulation) error) error {
+	sim, err := g.s.simulation(id)
+	if err != nil {
+		return grpcError(err)
+	}
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	return grpcError(handle(sim))
+}
+
+// 把仿真错误转换为 gRPC 状态错误，已是状态错误时不转换
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch err {
+	case errSimulationNotFound, errSliceOutOfRange:
+		return status.Error(codes.NotFound, err.Error())
+	case errEnvNotSet, errRunning, errNotRunning:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if _, ok := err.(validation.Errors); ok {
+		return invalidArgument(err)
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// 参数错误，校验错误列表逐条放在 BadRequest 详情的字段错误中
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	errs := validation.From(err)
+	br := &errdetails.BadRequest{FieldViolations: make([]*errdetails.BadRequest_FieldViolation, 0, len(errs))}
+	for _, e := range errs {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: e.Field, Description: e.Message})
+	}
+	if detailed, e := st.WithDetails(br); e == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func toSimulation(s *simulationStatus) *rpc.Simulation {
+	return &rpc.Simulation{
+		Id:        s.ID,
+		Caster:    s.Caster,
+		EnvSet:    s.EnvSet,
+		Running:   s.Running,
+		FieldSize: int32(s.FieldSize),
+	}
+}
+
+func toTemperatureField(data *calculator.TemperatureFieldData) *rpc.TemperatureField {
+	res := &rpc.TemperatureField{
+		XScale: int32(data.XScale),
+		YScale: int32(data.YScale),
+		ZScale: int32(data.ZScale),
+		Start:  int32(data.Start),
+		End:    int32(data.End),
+		IsFull: data.IsFull,
+		IsTail: data.IsTail,
+		Widths: data.Widths,
+	}
+	if data.Sides != nil {
+		res.Sides = &rpc.Sides{
+			Up:    toRows(data.Sides.Up),
+			Left:  toRows(data.Sides.Left),
+			Right: toRows(data.Sides.Right),
+			Front: toRows(data.Sides.Front),
+			Back:  toRows(data.Sides.Back),
+			Down:  toRows(data.Sides.Down),
+		}
+	}
+	if t := data.WidthTransition; t != nil {
+		res.WidthTransition = &rpc.WidthTransition{
+			StartDistance: t.StartDistance,
+			EndDistance:   t.EndDistance,
+			FromWidth:     t.FromWidth,
+			ToWidth:       t.ToWidth,
+		}
+	}
+	for _, p := range data.Geometry {
+		res.Geometry = append(res.Geometry, &rpc.GeometryPoint{Distance: p.Distance, X: p.X, Y: p.Y, Z: p.Z, Angle: p.Angle})
+	}
+	return res
+}
+
+// 复制二维数组，温度场推送数据会被下一次计算修改
+func toRows(data [][]float32) []*rpc.Row {
+	rows := make([]*rpc.Row, len(data))
+	for i, values := range data {
+		rows[i] = &rpc.Row{Values: append([]float32(nil), values...)}
+	}
+	return rows
+}
+
+func toPoints(data [][2]float32) []*rpc.Point {
+	points := make([]*rpc.Point, len(data))
+	for i, p := range data {
+		points[i] = &rpc.Point{X: p[0], Y: p[1]}
+	}
+	return points
+}
+
+func toEnv(env *rpc.Env) model.Env {
+	if env == nil {
+		return model.Env{}
+	}
+	res := model.Env{
+		Caster:           env.Caster,
+		LevelHeight:      env.LevelHeight,
+		SteelValue:       int(env.SteelValue),
+		StartTemperature: env.StartTemperature,
+		DragSpeed:        env.DragSpeed,
+	}
+	if md := env.Md; md != nil {
+		res.Md = model.Md{
+			NarrowSurfaceIn:     md.NarrowSurfaceIn,
+			NarrowSurfaceOut:    md.NarrowSurfaceOut,
+			NarrowSurfaceVolume: md.NarrowSurfaceVolume,
+			WideSurfaceIn:       md.WideSurfaceIn,
+			WideSurfaceOut:      md.WideSurfaceOut,
+			WideSurfaceVolume:   md.WideSurfaceVolume,
+		}
+	}
+	if c := env.Coordinate; c != nil {
+		res.Coordinate = model.Coordinate{
+			R:                   c.R,
+			LevelHeight:         c.LevelHeight,
+			ArcStartDistance:    c.ArcStartDistance,
+			ArcEndDistance:      c.ArcEndDistance,
+			CenterStartDistance: c.CenterStartDistance,
+			CenterEndDistance:   c.CenterEndDistance,
+			MdLength:            int(c.MdLength),
+			Width:               int(c.Width),
+			Length:              int(c.Length),
+			ZLength:             int(c.ZLength),
+			ZScale:              int(c.ZScale),
+			XScale:              int(c.XScale),
+			YScale:              int(c.YScale),
+		}
+		for _, z := range c.BendingZones {
+			res.Coordinate.BendingZones = append(res.Coordinate.BendingZones, model.BendingZone{
+				StartDistance: z.StartDistance,
+				EndDistance:   z.EndDistance,
+				StartRadius:   z.StartRadius,
+				EndRadius:     z.EndRadius,
+			})
+		}
+	}
+	for _, s := range env.SecondaryCoolingWaterCfg {
+		res.SecondaryCoolingWaterCfg = append(res.SecondaryCoolingWaterCfg, model.SecondaryCoolingWaterSection{
+			SprayWaterTemperature: s.SprayWaterTemperature,
+			InnerArcWaterVolume:   s.InnerArcWaterVolume,
+			NarrowSideWaterVolume: s.NarrowSideWaterVolume,
+			Fuqie1Volume:          s.Fuqie_1Volume,
+			Fuqie2Volume:          s.Fuqie_2Volume,
+		})
+	}
+	for _, z := range env.CoolingZoneCfg {
+		res.CoolingZoneCfg = append(res.CoolingZoneCfg, model.CoolingZone{
+			ZoneName:    z.ZoneName,
+			Start:       int(z.Start),
+			End:         int(z.End),
+			Medium:      int(z.Medium),
+			EndDistance: z.EndDistance,
+		})
+	}
+	return res
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
+	"lz/caster"
+	"lz/conf"
+	"lz/model"
+	"lz/rpc"
+	"net"
+	"testing"
+	"time"
+)
+
+// 取出参数错误中的 BadRequest 详情
+func badRequest(err error) *errdetails.BadRequest {
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			return br
+		}
+	}
+	return nil
+}
+
+func newTestGrpc(t *testing.T, casters *caster.Repository) rpc.CalculatorClient {
+	s := &Server{
+		casters:     casters,
+		simulations: make(map[string]*simulation),
+	}
+	lis := bufconn.Listen(1 << 20)
+	gs := s.newGrpcServer()
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rpc.NewCalculatorClient(conn)
+}
+
+func TestGrpc(t *testing.T) {
+	client := newTestGrpc(t, caster.NewRepository(t.TempDir()))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sim, err := client.CreateSimulation(ctx, &emptypb.Empty{})
+	if err != nil || sim.Id == "" || sim.EnvSet {
+		t.Fatal("新建仿真失败:", sim, err)
+	}
+	req := &rpc.SimulationRequest{SimulationId: sim.Id}
+
+	_, err = client.GetSimulation(ctx, &rpc.SimulationRequest{SimulationId: "404"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatal("仿真不存在时应返回 NotFound:", err)
+	}
+	_, err = client.GetSlice(ctx, &rpc.GetSliceRequest{SimulationId: sim.Id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatal("未设置计算环境时应返回 FailedPrecondition:", err)
+	}
+	_, err = client.SetEnv(ctx, &rpc.SetEnvRequest{SimulationId: sim.Id, Env: &rpc.Env{Caster: "missing"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("铸机不存在时应返回 InvalidArgument:", err)
+	}
+	if br := badRequest(err); br == nil || len(br.FieldViolations) != 1 || br.FieldViolations[0].Description == "" {
+		t.Fatal("参数错误应带有 BadRequest 详情:", err)
+	}
+	stream, err := client.Run(ctx, req)
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatal("未设置计算环境时不能开始计算:", err)
+	}
+	if _, err = client.DeleteSimulation(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Stop(ctx, req); status.Code(err) != codes.NotFound {
+		t.Fatal("仿真已删除:", err)
+	}
+}
+
+func TestGrpcSimulation(t *testing.T) {
+	client := newTestGrpc(t, caster.NewRepository(conf.AppConfig.CasterHomePath))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sim, err := client.CreateSimulation(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &rpc.SimulationRequest{SimulationId: sim.Id}
+	if _, err = client.SetEnv(ctx, &rpc.SetEnvRequest{SimulationId: sim.Id, Env: toRpcEnv(testEnv(t))}); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := client.Run(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	field, err := stream.Recv()
+	if err != nil || field.End <= field.Start {
+		t.Fatal("没有收到温度场:", field, err)
+	}
+	if sim, err = client.GetSimulation(ctx, req); err != nil || !sim.EnvSet || !sim.Running || sim.Caster != "caster" || sim.FieldSize == 0 {
+		t.Fatal("仿真状态不正确:", sim, err)
+	}
+	slice, err := client.GetSlice(ctx, &rpc.GetSliceRequest{SimulationId: sim.Id})
+	if err != nil || len(slice.Slice) == 0 {
+		t.Fatal("获取切片失败:", err)
+	}
+
+	// 已有计算器时不能使用不同的铸坯断面，校验错误放在 BadRequest 详情中
+	other, err := client.CreateSimulation(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	narrow := toRpcEnv(testEnv(t))
+	narrow.Coordinate.Width -= 20
+	_, err = client.SetEnv(ctx, &rpc.SetEnvRequest{SimulationId: other.Id, Env: narrow})
+	if br := badRequest(err); status.Code(err) != codes.InvalidArgument || br == nil || len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "coordinate" {
+		t.Fatal("铸坯断面不一致时应返回字段错误:", err)
+	}
+	client.DeleteSimulation(ctx, &rpc.SimulationRequest{SimulationId: other.Id})
+
+	// 删除后计算停止，推送流结束
+	if _, err = client.DeleteSimulation(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if err != io.EOF {
+		t.Fatal("删除仿真后推送流应正常结束:", err)
+	}
+	if _, err = client.GetSimulation(ctx, req); status.Code(err) != codes.NotFound {
+		t.Fatal("仿真已删除:", err)
+	}
+}
+
+// 测试用的计算环境转换为 gRPC 消息
+func toRpcEnv(env model.Env) *rpc.Env {
+	c := env.Coordinate
+	res := &rpc.Env{
+		Caster:           env.Caster,
+		LevelHeight:      env.LevelHeight,
+		SteelValue:       int32(env.SteelValue),
+		StartTemperature: env.StartTemperature,
+		DragSpeed:        env.DragSpeed,
+		Md: &rpc.Md{
+			NarrowSurfaceIn:     env.Md.NarrowSurfaceIn,
+			NarrowSurfaceOut:    env.Md.NarrowSurfaceOut,
+			NarrowSurfaceVolume: env.Md.NarrowSurfaceVolume,
+			WideSurfaceIn:       env.Md.WideSurfaceIn,
+			WideSurfaceOut:      env.Md.WideSurfaceOut,
+			WideSurfaceVolume:   env.Md.WideSurfaceVolume,
+		},
+		Coordinate: &rpc.Coordinate{
+			R:                   c.R,
+			LevelHeight:         c.LevelHeight,
+			ArcStartDistance:    c.ArcStartDistance,
+			ArcEndDistance:      c.ArcEndDistance,
+			CenterStartDistance: c.CenterStartDistance,
+			CenterEndDistance:   c.CenterEndDistance,
+			MdLength:            int32(c.MdLength),
+			Width:               int32(c.Width),
+			Length:              int32(c.Length),
+			ZLength:             int32(c.ZLength),
+			ZScale:              int32(c.ZScale),
+			XScale:              int32(c.XScale),
+			YScale:              int32(c.YScale),
+		},
+	}
+	for _, z := range c.BendingZones {
+		res.Coordinate.BendingZones = append(res.Coordinate.BendingZones, &rpc.BendingZone{
+			StartDistance: z.StartDistance,
+			EndDistance:   z.EndDistance,
+			StartRadius:   z.StartRadius,
+			EndRadius:     z.EndRadius,
+		})
+	}
+	for _, s := range env.SecondaryCoolingWaterCfg {
+		res.SecondaryCoolingWaterCfg = append(res.SecondaryCoolingWaterCfg, &rpc.SecondaryCoolingWaterSection{
+			SprayWaterTemperature: s.SprayWaterTemperature,
+			InnerArcWaterVolume:   s.InnerArcWaterVolume,
+			NarrowSideWaterVolume: s.NarrowSideWaterVolume,
+			Fuqie_1Volume:         s.Fuqie1Volume,
+			Fuqie_2Volume:         s.Fuqie2Volume,
+		})
+	}
+	for _, z := range env.CoolingZoneCfg {
+		res.CoolingZoneCfg = append(res.CoolingZoneCfg, &rpc.CoolingZone{
+			ZoneName:    z.ZoneName,
+			Start:       int32(z.Start),
+			End:         int32(z.End),
+			Medium:      int32(z.Medium),
+			EndDistance: z.EndDistance,
+		})
+	}
+	return res
+}
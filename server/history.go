@@ -0,0 +1,74 @@
+package server
+
+import (
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"lz/calculator"
+	"lz/conf"
+	"lz/historian"
+	"lz/model"
+	"time"
+)
+
+// 按记录周期把计算结果写入历史数据库，只在 pushData 协程中使用
+type recorder struct {
+	history        *historian.Historian
+	caster         string
+	interval       time.Duration
+	fieldInterval  time.Duration
+	lastIndicators time.Time
+	lastField      time.Time
+}
+
+// 没有启用历史数据库时返回 nil
+func (h *Hub) newRecorder() *recorder {
+	if h.history == nil {
+		return nil
+	}
+	r := &recorder{
+		history:       h.history,
+		interval:      time.Duration(conf.AppConfig.HistoryInterval) * time.Second,
+		fieldInterval: time.Duration(conf.AppConfig.HistoryFieldInterval) * time.Second,
+	}
+	if h.selected != nil {
+		r.caster = h.selected.Name
+	}
+	return r
+}
+
+// 到达记录周期时记录关键指标和降采样后的温度场
+func (r *recorder) record(c calculator.Calculator) {
+	if r == nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(r.lastIndicators) >= r.interval {
+		r.lastIndicators = now
+		if err := r.history.Write(model.HistoryIndicators, r.caster, c.GenerateIndicators()); err != nil {
+			log.WithField("err", err).Error("记录关键指标失败")
+		}
+	}
+	if now.Sub(r.lastField) >= r.fieldInterval {
+		r.lastField = now
+		snapshot := c.GenerateFieldSnapshot(historian.FieldZStride, historian.FieldXYStride)
+		if err := r.history.Write(model.HistoryField, r.caster, snapshot); err != nil {
+			log.WithField("err", err).Error("记录温度场失败")
+		}
+	}
+}
+
+// 查询历史数据并回复，查询可能较慢，在单独的协程中运行
+func (h *Hub) queryHistory(q model.HistoryQuery) {
+	res, err := h.history.Query(q)
+	if err != nil {
+		log.WithField("err", err).Error("查询历史数据失败")
+		h.replyError("history_error", err)
+		return
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		log.WithField("err", err).Error("历史数据json解析失败")
+		return
+	}
+	h.reply("history_result", string(data))
+}
@@ -1,13 +1,18 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
-	"io/ioutil"
 	"lz/calculator"
-	"lz/conf"
+	"lz/caster"
+	"lz/connector"
+	"lz/historian"
 	"lz/model"
+	"lz/publisher"
+	"lz/validation"
 	"strconv"
 	"sync"
 	"time"
@@ -15,17 +20,27 @@ import (
 
 // Hub maintains the set of active clients and broadcasts messages to the clients.
 type Hub struct {
-	c    calculator.Calculator
-	conn *websocket.Conn
+	c        calculator.Calculator
+	conn     *websocket.Conn
+	casters  *caster.Repository   // 铸机库
+	selected *model.Caster        // 当前选择的铸机
+	history  *historian.Historian // 历史数据库，为 nil 时不记录历史数据
 	// request
 	msg chan model.Msg
 	// response
 	selectCaster         chan string
+	listCasters          chan struct{}
+	createCaster         chan model.Caster
+	updateCaster         chan model.Caster
+	deleteCaster         chan string
+	casterHistory        chan string
 	envSet               chan model.Env
 	changeInitialTemp    chan float32
 	changeNarrowSurface  chan model.NarrowSurface
 	changeWideSurface    chan model.WideSurface
+	changeMd             chan model.Md
 	changeV              chan float32
+	changeWidth          chan model.WidthChange
 	started              chan struct{}
 	stopped              chan struct{}
 	tailStart            chan struct{} // 拉尾坯
@@ -38,6 +53,43 @@ type Hub struct {
 	generateVerticalSlice1 chan struct{}
 	generateVerticalSlice2 chan model.VerticalReqData
 	generateShellCurves    chan struct{}
+	generateSegments       chan struct{}
+	setSoftReduction       chan model.SoftReductionCfg
+	setNozzleStates        chan []model.NozzleState
+	nozzleReport           chan struct{}
+	setDynamicCooling      chan model.DynamicCoolingCfg
+	setWaterTables         chan []model.WaterTable
+	setCoolingMode         chan string
+	optimize               chan model.OptimizeCfg
+	cancelOptimize         chan struct{}
+	speedSchedule          chan []model.SpeedPoint
+	changeSecondaryCooling chan []model.SecondaryCoolingChange
+	stopSpeedSchedule      chan struct{}
+	setTundish             chan model.TundishCfg
+	stopTundish            chan struct{}
+	ladleChange            chan model.LadleChange
+	superheatHistory       chan struct{}
+	heatEvent              chan model.HeatEvent
+	heatReport             chan struct{}
+	moldLevel              chan float32
+	setMoldLevel           chan model.MoldLevelCfg
+	setPyrometers          chan model.PyrometerCfg
+	pyrometerReadings      chan []model.PyrometerReading
+	connectOpcUa           chan model.OpcUaCfg
+	connectModbus          chan model.ModbusCfg
+	plantStatus            chan struct{}
+	disconnectPlant        chan struct{}
+	connectMqtt            chan model.MqttCfg
+	disconnectMqtt         chan struct{}
+	historyQuery           chan model.HistoryQuery
+	disconnected           chan struct{}      // 连接断开，释放计算器
+	pushing                sync.WaitGroup     // 正在运行的温度场推送协程
+	optimizing             context.CancelFunc // 正在运行的优化任务，没有时为 nil
+	plant                  context.CancelFunc // 正在运行的现场数据源，没有时为 nil
+	source                 connector.Source
+	publishing             context.CancelFunc // 正在运行的 MQTT 发布，没有时为 nil
+	mqtt                   *publisher.Mqtt
+	jobMu                  sync.Mutex
 
 	mu sync.Mutex
 }
@@ -46,11 +98,18 @@ func NewHub() *Hub {
 	return &Hub{
 		msg:                 make(chan model.Msg, 10),
 		selectCaster:        make(chan string, 10),
+		listCasters:         make(chan struct{}, 10),
+		createCaster:        make(chan model.Caster, 10),
+		updateCaster:        make(chan model.Caster, 10),
+		deleteCaster:        make(chan string, 10),
+		casterHistory:       make(chan string, 10),
 		envSet:              make(chan model.Env, 10),
 		changeInitialTemp:   make(chan float32, 10),
 		changeNarrowSurface: make(chan model.NarrowSurface, 10),
 		changeWideSurface:   make(chan model.WideSurface, 10),
+		changeMd:            make(chan model.Md, 10),
 		changeV:             make(chan float32, 10),
+		changeWidth:         make(chan model.WidthChange, 10),
 		started:             make(chan struct{}, 10),
 		stopped:             make(chan struct{}, 10),
 		tailStart:           make(chan struct{}, 10),
@@ -60,50 +119,183 @@ func NewHub() *Hub {
 		generateVerticalSlice1: make(chan struct{}, 10),
 		generateVerticalSlice2: make(chan model.VerticalReqData, 10),
 		generateShellCurves:    make(chan struct{}, 10),
+		generateSegments:       make(chan struct{}, 10),
+		setSoftReduction:       make(chan model.SoftReductionCfg, 10),
+		setNozzleStates:        make(chan []model.NozzleState, 10),
+		nozzleReport:           make(chan struct{}, 10),
+		setDynamicCooling:      make(chan model.DynamicCoolingCfg, 10),
+		setWaterTables:         make(chan []model.WaterTable, 10),
+		setCoolingMode:         make(chan string, 10),
+		optimize:               make(chan model.OptimizeCfg, 10),
+		cancelOptimize:         make(chan struct{}, 10),
+		speedSchedule:          make(chan []model.SpeedPoint, 10),
+		changeSecondaryCooling: make(chan []model.SecondaryCoolingChange, 10),
+		stopSpeedSchedule:      make(chan struct{}, 10),
+		setTundish:             make(chan model.TundishCfg, 10),
+		stopTundish:            make(chan struct{}, 10),
+		ladleChange:            make(chan model.LadleChange, 10),
+		superheatHistory:       make(chan struct{}, 10),
+		heatEvent:              make(chan model.HeatEvent, 10),
+		heatReport:             make(chan struct{}, 10),
+		moldLevel:              make(chan float32, 100),
+		setMoldLevel:           make(chan model.MoldLevelCfg, 10),
+		setPyrometers:          make(chan model.PyrometerCfg, 10),
+		pyrometerReadings:      make(chan []model.PyrometerReading, 100),
+		connectOpcUa:           make(chan model.OpcUaCfg, 10),
+		connectModbus:          make(chan model.ModbusCfg, 10),
+		plantStatus:            make(chan struct{}, 10),
+		disconnectPlant:        make(chan struct{}, 10),
+		connectMqtt:            make(chan model.MqttCfg, 10),
+		disconnectMqtt:         make(chan struct{}, 10),
+		historyQuery:           make(chan model.HistoryQuery, 10),
+		disconnected:           make(chan struct{}, 1),
 	}
 }
 
+// 累加各个通道中等待处理的消息数，通道在 NewHub 中创建后不再修改，可以并发读取
+// 新增通道时需要同时加在这里
+func (h *Hub) queueDepths(depths map[string]int) {
+	depths["msg"] += len(h.msg)
+	depths["selectCaster"] += len(h.selectCaster)
+	depths["listCasters"] += len(h.listCasters)
+	depths["createCaster"] += len(h.createCaster)
+	depths["updateCaster"] += len(h.updateCaster)
+	depths["deleteCaster"] += len(h.deleteCaster)
+	depths["casterHistory"] += len(h.casterHistory)
+	depths["envSet"] += len(h.envSet)
+	depths["changeInitialTemp"] += len(h.changeInitialTemp)
+	depths["changeNarrowSurface"] += len(h.changeNarrowSurface)
+	depths["changeWideSurface"] += len(h.changeWideSurface)
+	depths["changeMd"] += len(h.changeMd)
+	depths["changeV"] += len(h.changeV)
+	depths["changeWidth"] += len(h.changeWidth)
+	depths["started"] += len(h.started)
+	depths["stopped"] += len(h.stopped)
+	depths["tailStart"] += len(h.tailStart)
+	depths["startPushSliceDetail"] += len(h.startPushSliceDetail)
+	depths["stopPushSliceDetail"] += len(h.stopPushSliceDetail)
+	depths["generate"] += len(h.generate)
+	depths["generateSlice"] += len(h.generateSlice)
+	depths["generateVerticalSlice1"] += len(h.generateVerticalSlice1)
+	depths["generateVerticalSlice2"] += len(h.generateVerticalSlice2)
+	depths["generateShellCurves"] += len(h.generateShellCurves)
+	depths["generateSegments"] += len(h.generateSegments)
+	depths["setSoftReduction"] += len(h.setSoftReduction)
+	depths["setNozzleStates"] += len(h.setNozzleStates)
+	depths["nozzleReport"] += len(h.nozzleReport)
+	depths["setDynamicCooling"] += len(h.setDynamicCooling)
+	depths["setWaterTables"] += len(h.setWaterTables)
+	depths["setCoolingMode"] += len(h.setCoolingMode)
+	depths["optimize"] += len(h.optimize)
+	depths["cancelOptimize"] += len(h.cancelOptimize)
+	depths["speedSchedule"] += len(h.speedSchedule)
+	depths["changeSecondaryCooling"] += len(h.changeSecondaryCooling)
+	depths["stopSpeedSchedule"] += len(h.stopSpeedSchedule)
+	depths["setTundish"] += len(h.setTundish)
+	depths["stopTundish"] += len(h.stopTundish)
+	depths["ladleChange"] += len(h.ladleChange)
+	depths["superheatHistory"] += len(h.superheatHistory)
+	depths["heatEvent"] += len(h.heatEvent)
+	depths["heatReport"] += len(h.heatReport)
+	depths["moldLevel"] += len(h.moldLevel)
+	depths["setMoldLevel"] += len(h.setMoldLevel)
+	depths["setPyrometers"] += len(h.setPyrometers)
+	depths["pyrometerReadings"] += len(h.pyrometerReadings)
+	depths["connectOpcUa"] += len(h.connectOpcUa)
+	depths["connectModbus"] += len(h.connectModbus)
+	depths["plantStatus"] += len(h.plantStatus)
+	depths["disconnectPlant"] += len(h.disconnectPlant)
+	depths["connectMqtt"] += len(h.connectMqtt)
+	depths["disconnectMqtt"] += len(h.disconnectMqtt)
+	depths["historyQuery"] += len(h.historyQuery)
+	depths["disconnected"] += len(h.disconnected)
+}
+
+// 计算环境未设置时回复 env_not_set，h.c 只在 handleResponse 中读写，需在其中调用
+func (h *Hub) requireEnv() bool {
+	if h.c != nil {
+		return true
+	}
+	log.Warn("计算环境未设置")
+	h.replyError("env_not_set", errEnvNotSet)
+	return false
+}
+
 func (h *Hub) handleResponse() {
 	defer func() {
 		log.Fatal("停止handleResponse")
 	}()
 	for {
 		select {
-		case fileName := <-h.selectCaster: // 选择铸机
-			data, err := ioutil.ReadFile(fileName)
+		case name := <-h.selectCaster: // 选择铸机
+			c, err := h.casters.Get(name)
 			if err != nil {
-				log.Println("err", err)
-				return
+				log.WithField("err", err).Error("读取铸机失败")
+				h.replyError("caster_error", err)
+				break
 			}
-			reply := model.Msg{
-				Type:    "caster_info",
-				Content: string(data),
+			h.selected = c
+			data, err := json.Marshal(c)
+			if err != nil {
+				log.WithField("err", err).Error("铸机数据json解析失败")
+				break
 			}
-			h.mu.Lock()
-			err = h.conn.WriteJSON(&reply)
-			h.mu.Unlock()
+			h.reply("caster_info", string(data))
+		case <-h.listCasters: // 铸机列表
+			names, err := h.casters.List()
 			if err != nil {
-				log.WithField("err", err).Error("回复消息失败")
+				log.WithField("err", err).Error("读取铸机列表失败")
+				h.replyError("caster_error", err)
+				break
+			}
+			data, err := json.Marshal(names)
+			if err != nil {
+				log.WithField("err", err).Error("铸机列表json解析失败")
+				break
+			}
+			h.reply("caster_list", string(data))
+		case c := <-h.createCaster: // 新建铸机
+			if err := h.casters.Create(&c); err != nil {
+				log.WithField("err", err).Error("新建铸机失败")
+				h.replyError("caster_error", err)
+				break
+			}
+			h.reply("caster_created", c.Name)
+		case c := <-h.updateCaster: // 更新铸机
+			if err := h.casters.Update(&c); err != nil {
+				log.WithField("err", err).Error("更新铸机失败")
+				h.replyError("caster_error", err)
+				break
 			}
+			h.reply("caster_updated", c.Name)
+		case name := <-h.deleteCaster: // 删除铸机
+			if err := h.casters.Delete(name); err != nil {
+				log.WithField("err", err).Error("删除铸机失败")
+				h.replyError("caster_error", err)
+				break
+			}
+			h.reply("caster_deleted", name)
+		case name := <-h.casterHistory: // 铸机历史版本
+			versions, err := h.casters.History(name)
+			if err != nil {
+				log.WithField("err", err).Error("读取铸机历史版本失败")
+				h.replyError("caster_error", err)
+				break
+			}
+			data, err := json.Marshal(versions)
+			if err != nil {
+				log.WithField("err", err).Error("铸机历史版本json解析失败")
+				break
+			}
+			h.reply("caster_history", string(data))
 		case env := <-h.envSet: // 设置计算环境
-			if h.c == nil {
-				// 初始化铸坯尺寸
-				calculator.ZLength = env.Coordinate.ZLength
-				calculator.Length = env.Coordinate.Length / 2
-				calculator.Width = env.Coordinate.Width / 2
-				log.Info("ZLength:", calculator.ZLength, " ,Length:", calculator.Length, " ,Width:", calculator.Width)
-				h.c = calculator.NewCalculatorWithArrDeque(nil)
-			}
-			h.c.GetCastingMachine().SetFromJson(env.Coordinate) // 初始化铸机尺寸
-			data, err := ioutil.ReadFile(conf.AppConfig.NozzleConfigFile)
-			if err != nil {
-				log.Println("err", err)
-				return
+			c, selected, err := applyEnv(h.casters, h.selected, h.c, env)
+			if err != nil {
+				log.WithField("err", err).Error("设置计算环境失败")
+				h.replyError("env_invalid", err)
+				break
 			}
-			h.c.GetCastingMachine().SetCoolerConfig(env, data)     // 设置冷却参数
-			h.c.GetCastingMachine().SetV(env.DragSpeed)            // 设置拉速
-			h.c.InitSteel(env.SteelValue, h.c.GetCastingMachine()) // 设置钢种物性参数
-			h.c.InitPushData(env.Coordinate)                       // 设置推送数据相关参数
+			h.c, h.selected = c, selected
 			reply := model.Msg{
 				Type:    "env_set",
 				Content: "env is set",
@@ -115,6 +307,10 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("回复消息失败")
 			}
 		case temp := <-h.changeInitialTemp: // 改变初始浇铸温度
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().StopTundish() // 手动设置后不再按中间包温度模型改变
 			h.c.GetCastingMachine().SetStartTemperature(temp)
 			reply := model.Msg{
 				Type:    "initial_temp_set",
@@ -127,8 +323,14 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("回复消息失败")
 			}
 		case narrowSurface := <-h.changeNarrowSurface: // 改变结晶器窄面水量
+			if !h.requireEnv() {
+				break
+			}
 			h.c.GetCastingMachine().SetNarrowSurfaceIn(narrowSurface.In)
 			h.c.GetCastingMachine().SetNarrowSurfaceOut(narrowSurface.Out)
+			if narrowSurface.Volume > 0 {
+				h.c.GetCastingMachine().SetNarrowWaterVolume(narrowSurface.Volume)
+			}
 			reply := model.Msg{
 				Type:    "narrow_surface_temp_set",
 				Content: "narrow_surface_temp_set",
@@ -140,8 +342,14 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("回复消息失败")
 			}
 		case wideSurface := <-h.changeWideSurface: // 改变结晶器宽面水量
+			if !h.requireEnv() {
+				break
+			}
 			h.c.GetCastingMachine().SetWideSurfaceIn(wideSurface.In)
 			h.c.GetCastingMachine().SetWideSurfaceOut(wideSurface.Out)
+			if wideSurface.Volume > 0 {
+				h.c.GetCastingMachine().SetWideWaterVolume(wideSurface.Volume)
+			}
 			reply := model.Msg{
 				Type:    "wide_surface_temp_set",
 				Content: "wide_surface_temp_set",
@@ -152,7 +360,17 @@ func (h *Hub) handleResponse() {
 			if err != nil {
 				log.WithField("err", err).Error("回复消息失败")
 			}
-		case v := <-h.changeV: // 改变拉速
+		case md := <-h.changeMd: // 同时改变结晶器宽面、窄面冷却参数
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().SetMd(md)
+			h.reply("md_set", "md_set")
+		case v := <-h.changeV: // 改变拉速，手动改变拉速时停止播放拉速曲线
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().StopSpeedSchedule()
 			h.c.GetCastingMachine().SetV(v)
 			reply := model.Msg{
 				Type:    "v_set",
@@ -164,11 +382,26 @@ func (h *Hub) handleResponse() {
 			if err != nil {
 				log.WithField("err", err).Error("回复消息失败")
 			}
+		case widthChange := <-h.changeWidth: // 在线调宽
+			if !h.requireEnv() {
+				break
+			}
+			// 温度场网格按 env_set 时的宽度划分，调宽后的宽度不能超过该宽度
+			if errs := validation.WidthChange("width_change", widthChange, calculator.Length*2); len(errs) > 0 {
+				h.replyError("width_change_invalid", errs)
+				break
+			}
+			h.c.GetCastingMachine().StartWidthChange(widthChange.Width, time.Duration(widthChange.Duration*float32(time.Second)))
+			h.reply("width_change_started", "width_change_started")
 		case <-h.started: // 开始计算
+			if !h.requireEnv() {
+				break
+			}
 			// 从calculator里面的hub中获取是否有
 			h.c.GetCalcHub().StartSignal()
-			go h.c.Run()    // 不断计算
-			go h.pushData() // 获取推送的计算结果到前端
+			go h.c.Run() // 不断计算
+			h.pushing.Add(1)
+			go h.pushData(h.newRecorder()) // 获取推送的计算结果到前端
 			reply := model.Msg{
 				Type:    "started",
 				Content: "Started",
@@ -180,6 +413,9 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("回复消息失败")
 			}
 		case <-h.stopped: // 停止计算
+			if !h.requireEnv() {
+				break
+			}
 			h.c.GetCalcHub().StopSignal()
 			reply := model.Msg{
 				Type:    "stopped",
@@ -192,6 +428,9 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("回复消息失败")
 			}
 		case <-h.tailStart: // 拉尾坯 todo
+			if !h.requireEnv() {
+				break
+			}
 			h.c.SetStateTail()
 			reply := model.Msg{
 				Type:    "tail_start",
@@ -204,6 +443,13 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("回复消息失败")
 			}
 		case index := <-h.generateSlice: // 横切面信息
+			if !h.requireEnv() {
+				break
+			}
+			if index >= h.c.GetFieldSize() {
+				log.Warn("切片下标越界")
+				break
+			}
 			reply := model.Msg{
 				Type: "slice_generated",
 			}
@@ -221,6 +467,9 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("发送温度场切片推送消息失败")
 			}
 		case <-h.generateVerticalSlice1: // 纵切面曲线
+			if !h.requireEnv() {
+				break
+			}
 			reply := model.Msg{
 				Type: "vertical_slice1_generated",
 			}
@@ -238,6 +487,9 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("发送纵向切片1推送消息失败")
 			}
 		case reqData := <-h.generateVerticalSlice2: // 纵切面云图
+			if !h.requireEnv() {
+				break
+			}
 			reply := model.Msg{
 				Type: "vertical_slice2_generated",
 			}
@@ -255,6 +507,9 @@ func (h *Hub) handleResponse() {
 				log.WithField("err", err).Error("发送纵向切片2推送消息失败")
 			}
 		case <-h.generateShellCurves:
+			if !h.requireEnv() {
+				break
+			}
 			reply := model.Msg{
 				Type: "shell_curves_generated",
 			}
@@ -271,6 +526,247 @@ func (h *Hub) handleResponse() {
 			if err != nil {
 				log.WithField("err", err).Error("发送坯壳厚度推送消息失败")
 			}
+		case <-h.generateSegments: // 扇形段坯壳厚度
+			if !h.requireEnv() {
+				break
+			}
+			data, err := json.Marshal(h.c.GenerateSegmentsData())
+			if err != nil {
+				log.WithField("err", err).Error("扇形段推送数据json解析失败")
+				break
+			}
+			h.reply("segments_generated", string(data))
+		case cfg := <-h.setSoftReduction: // 动态轻压下配置
+			if !h.requireEnv() {
+				break
+			}
+			h.c.SetSoftReductionCfg(cfg)
+			h.reply("soft_reduction_set", "soft_reduction_set")
+		case states := <-h.setNozzleStates: // 喷嘴堵塞、关闭
+			if !h.requireEnv() {
+				break
+			}
+			err := h.c.GetCastingMachine().SetNozzleStates(states)
+			if err != nil {
+				log.WithField("err", err).Error("设置喷嘴状态失败")
+				h.replyError("nozzle_state_invalid", err)
+				break
+			}
+			h.reply("nozzle_state_set", "nozzle_state_set")
+		case <-h.nozzleReport: // 喷嘴故障报告
+			if !h.requireEnv() {
+				break
+			}
+			data, err := json.Marshal(h.c.GenerateNozzleReport())
+			if err != nil {
+				log.WithField("err", err).Error("喷嘴故障报告json解析失败")
+				break
+			}
+			h.reply("nozzle_report", string(data))
+		case cfg := <-h.setDynamicCooling: // 动态二冷控制配置
+			if !h.requireEnv() {
+				break
+			}
+			h.c.SetDynamicCoolingCfg(cfg)
+			h.reply("dynamic_cooling_set", "dynamic_cooling_set")
+		case tables := <-h.setWaterTables: // 水表
+			if !h.requireEnv() {
+				break
+			}
+			h.c.SetWaterTables(tables)
+			h.reply("water_tables_set", "water_tables_set")
+		case mode := <-h.setCoolingMode: // 二冷控制模式
+			if !h.requireEnv() {
+				break
+			}
+			err := h.c.SetCoolingMode(mode)
+			if err != nil {
+				log.WithField("err", err).Error("切换二冷控制模式失败")
+				h.replyError("cooling_mode_invalid", err)
+				break
+			}
+			h.reply("cooling_mode_set", mode)
+		case cfg := <-h.optimize: // 离线冷却制度优化
+			if !h.requireEnv() {
+				break
+			}
+			h.jobMu.Lock()
+			if h.optimizing != nil {
+				h.jobMu.Unlock()
+				h.replyError("optimize_error", errors.New("优化任务正在运行"))
+				break
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			h.optimizing = cancel
+			h.jobMu.Unlock()
+			h.reply("optimize_started", "optimize_started")
+			go h.runOptimize(ctx, cfg)
+		case changes := <-h.changeSecondaryCooling: // 修改二冷水量
+			if !h.requireEnv() {
+				break
+			}
+			err := h.c.GetCastingMachine().SetSecondaryCoolingWater(changes)
+			if err != nil {
+				log.WithField("err", err).Error("修改二冷水量失败")
+				h.replyError("secondary_cooling_invalid", err)
+				break
+			}
+			h.reply("secondary_cooling_changed", "secondary_cooling_changed")
+		case points := <-h.speedSchedule: // 播放拉速曲线
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().StartSpeedSchedule(points)
+			h.reply("speed_schedule_started", "speed_schedule_started")
+		case <-h.stopSpeedSchedule: // 停止播放拉速曲线
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().StopSpeedSchedule()
+			h.reply("speed_schedule_stopped", "speed_schedule_stopped")
+		case cfg := <-h.setTundish: // 中间包温度模型
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().StartTundish(cfg)
+			h.reply("tundish_started", "tundish_started")
+		case <-h.stopTundish: // 停止中间包温度模型
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().StopTundish()
+			h.reply("tundish_stopped", "tundish_stopped")
+		case ladle := <-h.ladleChange: // 换包
+			if !h.requireEnv() {
+				break
+			}
+			h.c.GetCastingMachine().ChangeLadle(ladle)
+			h.reply("ladle_changed", "ladle_changed")
+		case <-h.superheatHistory: // 过热度历史
+			if !h.requireEnv() {
+				break
+			}
+			data, err := json.Marshal(h.c.GenerateSuperheatHistory())
+			if err != nil {
+				log.WithField("err", err).Error("过热度历史json解析失败")
+				break
+			}
+			h.reply("superheat_history", string(data))
+		case event := <-h.heatEvent: // 开浇、停浇和换包
+			if !h.requireEnv() {
+				break
+			}
+			err := h.c.HeatEvent(event)
+			if err != nil {
+				log.WithField("err", err).Error("处理炉次事件失败")
+				h.replyError("heat_event_invalid", err)
+				break
+			}
+			h.reply("heat_event_applied", "heat_event_applied")
+		case <-h.heatReport: // 炉次报告
+			if !h.requireEnv() {
+				break
+			}
+			data, err := json.Marshal(h.c.GenerateHeatReport())
+			if err != nil {
+				log.WithField("err", err).Error("炉次报告json解析失败")
+				break
+			}
+			h.reply("heat_report", string(data))
+		case level := <-h.moldLevel: // 液面测量值，频率较高，只回复错误
+			if !h.requireEnv() {
+				break
+			}
+			if errs := validation.MoldLevel("mold_level.level", level, h.c.GetCastingMachine().Coordinate.MdLength); len(errs) > 0 {
+				h.replyError("mold_level_invalid", errs)
+				break
+			}
+			h.c.GetCastingMachine().AddMoldLevel(level)
+		case cfg := <-h.setMoldLevel: // 液面设定
+			if !h.requireEnv() {
+				break
+			}
+			if errs := validation.MoldLevelCfg("mold_level", cfg, h.c.GetCastingMachine().Coordinate.MdLength); len(errs) > 0 {
+				h.replyError("mold_level_invalid", errs)
+				break
+			}
+			h.c.GetCastingMachine().SetMoldLevelCfg(cfg)
+			h.reply("mold_level_set", "mold_level_set")
+		case cfg := <-h.setPyrometers: // 测温仪设置
+			if !h.requireEnv() {
+				break
+			}
+			if errs := validation.Pyrometers("pyrometers", cfg, h.c.GetCastingMachine().Coordinate); len(errs) > 0 {
+				h.replyError("pyrometer_invalid", errs)
+				break
+			}
+			h.c.GetCastingMachine().SetPyrometers(cfg)
+			h.reply("pyrometers_set", "pyrometers_set")
+		case readings := <-h.pyrometerReadings: // 测温仪测量值，频率较高，只回复错误
+			if !h.requireEnv() {
+				break
+			}
+			for _, reading := range readings {
+				if err := h.c.GetCastingMachine().AddPyrometerReading(reading.ID, reading.Temperature); err != nil {
+					h.replyError("pyrometer_invalid", err)
+				}
+			}
+		case cfg := <-h.connectOpcUa: // 连接 OPC UA 数据源
+			if !h.requireEnv() {
+				break
+			}
+			o, err := connector.NewOpcUa(cfg, h.c.GetCastingMachine())
+			if err != nil {
+				log.WithField("err", err).Error("OPC UA 数据源配置错误")
+				h.replyError("opcua_error", err)
+				break
+			}
+			go h.runSource(h.startPlant(o), o, "opcua")
+		case cfg := <-h.connectModbus: // 连接 Modbus TCP 数据源
+			if !h.requireEnv() {
+				break
+			}
+			m := connector.NewModbus(cfg, h.c.GetCastingMachine())
+			go h.runSource(h.startPlant(m), m, "modbus")
+		case <-h.plantStatus: // 现场信号状态
+			h.jobMu.Lock()
+			source := h.source
+			h.jobMu.Unlock()
+			if source == nil {
+				h.replyError("plant_error", errors.New("没有连接现场数据源"))
+				break
+			}
+			data, err := json.Marshal(source.Status())
+			if err != nil {
+				log.WithField("err", err).Error("现场信号状态json解析失败")
+				break
+			}
+			h.reply("plant_status", string(data))
+		case <-h.disconnectPlant: // 断开现场数据源
+			h.stopPlant()
+			h.reply("plant_disconnected", "plant_disconnected")
+		case cfg := <-h.connectMqtt: // 连接 MQTT 服务器发布关键指标
+			if !h.requireEnv() {
+				break
+			}
+			go h.runMqtt(h.startMqtt(cfg), cfg)
+		case <-h.disconnectMqtt: // 停止 MQTT 发布
+			h.stopMqtt()
+			h.reply("mqtt_disconnected", "mqtt_disconnected")
+		case q := <-h.historyQuery: // 查询历史数据
+			if h.history == nil {
+				h.replyError("history_error", errors.New("没有启用历史数据存储"))
+				break
+			}
+			go h.queryHistory(q)
+		case <-h.cancelOptimize: // 取消优化
+			h.jobMu.Lock()
+			if h.optimizing != nil {
+				h.optimizing()
+			}
+			h.jobMu.Unlock()
+		case <-h.disconnected: // 连接断开
+			h.release()
 		default:
 			time.Sleep(10 * time.Millisecond)
 		}
@@ -287,15 +783,34 @@ func (h *Hub) handleRequest() {
 		case msg := <-h.msg:
 			switch msg.Type {
 			case "select_caster":
-				caster := msg.Content
-				fileName := conf.AppConfig.CasterHomePath + caster + ".json"
-				h.selectCaster <- fileName
+				h.selectCaster <- msg.Content
+			case "list_casters":
+				h.listCasters <- struct{}{}
+			case "create_caster", "update_caster":
+				var c model.Caster
+				err := json.Unmarshal([]byte(msg.Content), &c)
+				if err != nil {
+					log.WithField("err", err).Error("铸机json解析失败")
+					h.replyError("caster_error", err)
+					break
+				}
+				log.WithField("caster", c.Name).Info("获取到铸机配置")
+				if msg.Type == "create_caster" {
+					h.createCaster <- c
+				} else {
+					h.updateCaster <- c
+				}
+			case "delete_caster":
+				h.deleteCaster <- msg.Content
+			case "caster_history":
+				h.casterHistory <- msg.Content
 			case "env":
 				var env model.Env
 				err := json.Unmarshal([]byte(msg.Content), &env)
 				if err != nil {
-					log.Println("err", err)
-					return
+					log.WithField("err", err).Error("计算环境参数json解析失败")
+					h.replyError("env_invalid", err)
+					break
 				}
 				log.WithField("env", env).Info("获取到计算环境参数")
 				h.envSet <- env
@@ -305,6 +820,10 @@ func (h *Hub) handleRequest() {
 					log.Println("err", err)
 					return
 				}
+				if errs := validation.Signal("initial_temp", model.SignalTundishTemperature, float32(temp), 0); len(errs) > 0 {
+					h.replyError("initial_temp_invalid", errs)
+					break
+				}
 				log.WithField("temp", temp).Info("获取到初始温度参数")
 				h.changeInitialTemp <- float32(temp)
 			case "change_narrow_surface":
@@ -314,6 +833,10 @@ func (h *Hub) handleRequest() {
 					log.Println("err", err)
 					return
 				}
+				if errs := validation.MdSurface("narrow_surface", narrowSurface.In, narrowSurface.Out, narrowSurface.Volume); len(errs) > 0 {
+					h.replyError("md_invalid", errs)
+					break
+				}
 				log.WithField("narrowSurface", narrowSurface).Info("获取到窄面温度参数")
 				h.changeNarrowSurface <- narrowSurface
 			case "change_wide_surface":
@@ -323,16 +846,48 @@ func (h *Hub) handleRequest() {
 					log.Println("err", err)
 					return
 				}
+				if errs := validation.MdSurface("wide_surface", wideSurface.In, wideSurface.Out, wideSurface.Volume); len(errs) > 0 {
+					h.replyError("md_invalid", errs)
+					break
+				}
 				log.WithField("wideSurface", wideSurface).Info("获取到宽面温度参数")
 				h.changeWideSurface <- wideSurface
+			case "change_md":
+				var md model.Md
+				err := json.Unmarshal([]byte(msg.Content), &md)
+				if err != nil {
+					log.WithField("err", err).Error("结晶器冷却参数json解析失败")
+					h.replyError("md_invalid", err)
+					break
+				}
+				if errs := validation.Md("md", md); len(errs) > 0 {
+					h.replyError("md_invalid", errs)
+					break
+				}
+				log.WithField("md", md).Info("获取到结晶器冷却参数")
+				h.changeMd <- md
 			case "change_v":
 				v, err := strconv.ParseFloat(msg.Content, 10)
 				if err != nil {
 					log.Println("err", err)
 					return
 				}
+				if errs := validation.Signal("v", model.SignalSpeed, float32(v), 0); len(errs) > 0 {
+					h.replyError("v_invalid", errs)
+					break
+				}
 				log.WithField("v", v).Info("获取到拉速参数")
 				h.changeV <- float32(v)
+			case "change_width":
+				var widthChange model.WidthChange
+				err := json.Unmarshal([]byte(msg.Content), &widthChange)
+				if err != nil {
+					log.WithField("err", err).Error("调宽参数json解析失败")
+					h.replyError("width_change_invalid", err)
+					break
+				}
+				log.WithField("widthChange", widthChange).Info("获取到调宽参数")
+				h.changeWidth <- widthChange
 			case "start":
 				log.Info("开始计算三维温度场")
 				h.started <- struct{}{}
@@ -349,7 +904,7 @@ func (h *Hub) handleRequest() {
 					log.WithField("err", err).Error("切片下标不是整数")
 					return
 				}
-				if index < 0 || int(index) >= h.c.GetFieldSize() {
+				if index < 0 {
 					log.Warn("切片下标越界")
 					break
 				}
@@ -374,6 +929,272 @@ func (h *Hub) handleRequest() {
 			case "generate_shell_curves":
 				log.Info("获取到生成坯壳厚度变化曲线的信号")
 				h.generateShellCurves <- struct{}{}
+			case "generate_segments":
+				log.Info("获取到生成扇形段坯壳厚度的信号")
+				h.generateSegments <- struct{}{}
+			case "set_soft_reduction":
+				var cfg model.SoftReductionCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("动态轻压下配置json解析失败")
+					h.replyError("soft_reduction_invalid", err)
+					break
+				}
+				if errs := validation.SoftReduction("soft_reduction", cfg); len(errs) > 0 {
+					h.replyError("soft_reduction_invalid", errs)
+					break
+				}
+				log.WithField("cfg", cfg).Info("获取到动态轻压下配置")
+				h.setSoftReduction <- cfg
+			case "set_nozzle_state":
+				var states []model.NozzleState
+				err := json.Unmarshal([]byte(msg.Content), &states)
+				if err != nil {
+					log.WithField("err", err).Error("喷嘴状态json解析失败")
+					h.replyError("nozzle_state_invalid", err)
+					break
+				}
+				if errs := validation.NozzleStates("nozzle_states", states); len(errs) > 0 {
+					h.replyError("nozzle_state_invalid", errs)
+					break
+				}
+				log.WithField("states", states).Info("获取到喷嘴状态")
+				h.setNozzleStates <- states
+			case "nozzle_report":
+				log.Info("获取到生成喷嘴故障报告的信号")
+				h.nozzleReport <- struct{}{}
+			case "set_dynamic_cooling":
+				var cfg model.DynamicCoolingCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("动态二冷控制配置json解析失败")
+					h.replyError("dynamic_cooling_invalid", err)
+					break
+				}
+				if errs := validation.DynamicCooling("dynamic_cooling", cfg); len(errs) > 0 {
+					h.replyError("dynamic_cooling_invalid", errs)
+					break
+				}
+				h.setDynamicCooling <- cfg
+			case "set_water_tables":
+				var tables []model.WaterTable
+				err := json.Unmarshal([]byte(msg.Content), &tables)
+				if err != nil {
+					log.WithField("err", err).Error("水表json解析失败")
+					h.replyError("water_tables_invalid", err)
+					break
+				}
+				if errs := validation.WaterTables("water_tables", tables); len(errs) > 0 {
+					h.replyError("water_tables_invalid", errs)
+					break
+				}
+				h.setWaterTables <- tables
+			case "set_cooling_mode":
+				log.WithField("mode", msg.Content).Info("获取到二冷控制模式")
+				h.setCoolingMode <- msg.Content
+			case "optimize":
+				var cfg model.OptimizeCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("优化请求json解析失败")
+					h.replyError("optimize_error", err)
+					break
+				}
+				if errs := validation.Optimize("optimize", cfg); len(errs) > 0 {
+					h.replyError("optimize_error", errs)
+					break
+				}
+				log.WithField("cfg", cfg).Info("获取到冷却制度优化请求")
+				h.optimize <- cfg
+			case "change_secondary_cooling":
+				var changes []model.SecondaryCoolingChange
+				err := json.Unmarshal([]byte(msg.Content), &changes)
+				if err != nil {
+					log.WithField("err", err).Error("二冷水量json解析失败")
+					h.replyError("secondary_cooling_invalid", err)
+					break
+				}
+				if errs := validation.SecondaryCoolingChanges("secondary_cooling", changes); len(errs) > 0 {
+					h.replyError("secondary_cooling_invalid", errs)
+					break
+				}
+				log.WithField("changes", changes).Info("获取到二冷水量")
+				h.changeSecondaryCooling <- changes
+			case "set_speed_schedule":
+				var points []model.SpeedPoint
+				err := json.Unmarshal([]byte(msg.Content), &points)
+				if err != nil {
+					log.WithField("err", err).Error("拉速曲线json解析失败")
+					h.replyError("speed_schedule_invalid", err)
+					break
+				}
+				if errs := validation.SpeedSchedule("speed_schedule", points); len(errs) > 0 {
+					h.replyError("speed_schedule_invalid", errs)
+					break
+				}
+				log.WithField("points", len(points)).Info("获取到拉速曲线")
+				h.speedSchedule <- points
+			case "stop_speed_schedule":
+				h.stopSpeedSchedule <- struct{}{}
+			case "set_tundish":
+				var cfg model.TundishCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("中间包温度模型json解析失败")
+					h.replyError("tundish_invalid", err)
+					break
+				}
+				if errs := validation.Tundish("tundish", cfg); len(errs) > 0 {
+					h.replyError("tundish_invalid", errs)
+					break
+				}
+				log.WithField("cfg", cfg).Info("获取到中间包温度模型")
+				h.setTundish <- cfg
+			case "stop_tundish":
+				h.stopTundish <- struct{}{}
+			case "ladle_change":
+				var ladle model.LadleChange
+				err := json.Unmarshal([]byte(msg.Content), &ladle)
+				if err != nil {
+					log.WithField("err", err).Error("换包事件json解析失败")
+					h.replyError("ladle_change_invalid", err)
+					break
+				}
+				if errs := validation.LadleChange("ladle", ladle); len(errs) > 0 {
+					h.replyError("ladle_change_invalid", errs)
+					break
+				}
+				log.WithField("ladle", ladle).Info("获取到换包事件")
+				h.ladleChange <- ladle
+			case "superheat_history":
+				h.superheatHistory <- struct{}{}
+			case "heat_event":
+				var event model.HeatEvent
+				err := json.Unmarshal([]byte(msg.Content), &event)
+				if err != nil {
+					log.WithField("err", err).Error("炉次事件json解析失败")
+					h.replyError("heat_event_invalid", err)
+					break
+				}
+				if errs := validation.HeatEvent("heat_event", event); len(errs) > 0 {
+					h.replyError("heat_event_invalid", errs)
+					break
+				}
+				log.WithField("event", event).Info("获取到炉次事件")
+				h.heatEvent <- event
+			case "heat_report":
+				h.heatReport <- struct{}{}
+			case "mold_level":
+				var level model.MoldLevel
+				err := json.Unmarshal([]byte(msg.Content), &level)
+				if err != nil {
+					log.WithField("err", err).Error("液面测量值json解析失败")
+					h.replyError("mold_level_invalid", err)
+					break
+				}
+				h.moldLevel <- level.Level
+			case "set_mold_level":
+				var cfg model.MoldLevelCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("液面设定json解析失败")
+					h.replyError("mold_level_invalid", err)
+					break
+				}
+				log.WithField("cfg", cfg).Info("获取到液面设定")
+				h.setMoldLevel <- cfg
+			case "set_pyrometers":
+				var cfg model.PyrometerCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("测温仪设置json解析失败")
+					h.replyError("pyrometer_invalid", err)
+					break
+				}
+				log.WithField("cfg", cfg).Info("获取到测温仪设置")
+				h.setPyrometers <- cfg
+			case "pyrometer_readings":
+				var readings []model.PyrometerReading
+				err := json.Unmarshal([]byte(msg.Content), &readings)
+				if err != nil {
+					log.WithField("err", err).Error("测温仪测量值json解析失败")
+					h.replyError("pyrometer_invalid", err)
+					break
+				}
+				var errs validation.Errors
+				for i, reading := range readings {
+					errs = append(errs, validation.PyrometerTemperature("pyrometer_readings["+strconv.Itoa(i)+"].temperature", reading.Temperature)...)
+				}
+				if len(errs) > 0 {
+					h.replyError("pyrometer_invalid", errs)
+					break
+				}
+				h.pyrometerReadings <- readings
+			case "connect_opcua":
+				var cfg model.OpcUaCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("OPC UA 数据源配置json解析失败")
+					h.replyError("opcua_error", err)
+					break
+				}
+				if errs := validation.OpcUa("opcua", cfg); len(errs) > 0 {
+					h.replyError("opcua_error", errs)
+					break
+				}
+				log.WithField("cfg", cfg).Info("获取到 OPC UA 数据源配置")
+				h.connectOpcUa <- cfg
+			case "connect_modbus":
+				var cfg model.ModbusCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("Modbus 数据源配置json解析失败")
+					h.replyError("modbus_error", err)
+					break
+				}
+				if errs := validation.Modbus("modbus", cfg); len(errs) > 0 {
+					h.replyError("modbus_error", errs)
+					break
+				}
+				log.WithField("cfg", cfg).Info("获取到 Modbus 数据源配置")
+				h.connectModbus <- cfg
+			case "plant_status":
+				h.plantStatus <- struct{}{}
+			case "disconnect_plant":
+				h.disconnectPlant <- struct{}{}
+			case "connect_mqtt":
+				var cfg model.MqttCfg
+				err := json.Unmarshal([]byte(msg.Content), &cfg)
+				if err != nil {
+					log.WithField("err", err).Error("MQTT 发布配置json解析失败")
+					h.replyError("mqtt_error", err)
+					break
+				}
+				if errs := validation.Mqtt("mqtt", cfg); len(errs) > 0 {
+					h.replyError("mqtt_error", errs)
+					break
+				}
+				log.WithField("broker", cfg.Broker).Info("获取到 MQTT 发布配置")
+				h.connectMqtt <- cfg
+			case "disconnect_mqtt":
+				h.disconnectMqtt <- struct{}{}
+			case "history_query":
+				var q model.HistoryQuery
+				err := json.Unmarshal([]byte(msg.Content), &q)
+				if err != nil {
+					log.WithField("err", err).Error("历史数据查询json解析失败")
+					h.replyError("history_error", err)
+					break
+				}
+				if errs := validation.HistoryQuery("history", q); len(errs) > 0 {
+					h.replyError("history_error", errs)
+					break
+				}
+				log.WithField("query", q).Info("获取到历史数据查询")
+				h.historyQuery <- q
+			case "cancel_optimize":
+				log.Info("获取到取消优化的信号")
+				h.cancelOptimize <- struct{}{}
 			default:
 				log.Warn("no such type")
 			}
@@ -383,8 +1204,166 @@ func (h *Hub) handleRequest() {
 	}
 }
 
+// 开始新的现场数据源，同一时间只有一个数据源，已有的数据源先断开
+func (h *Hub) startPlant(source connector.Source) context.Context {
+	h.jobMu.Lock()
+	defer h.jobMu.Unlock()
+	if h.plant != nil {
+		h.plant()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h.plant, h.source = cancel, source
+	return ctx
+}
+
+// 断开现场数据源
+// 连接断开后停止计算和后台任务，等待推送协程退出后释放计算器
+func (h *Hub) release() {
+	h.stopPlant()
+	h.stopMqtt()
+	h.jobMu.Lock()
+	if h.optimizing != nil {
+		h.optimizing()
+	}
+	h.jobMu.Unlock()
+	if h.c == nil {
+		return
+	}
+	if !h.c.GetCalcHub().Stopped() {
+		h.c.GetCalcHub().StopSignal()
+	}
+	h.pushing.Wait()
+	h.c.Close()
+	log.Info("连接断开，释放计算器")
+}
+
+func (h *Hub) stopPlant() {
+	h.jobMu.Lock()
+	defer h.jobMu.Unlock()
+	if h.plant != nil {
+		h.plant()
+		h.plant, h.source = nil, nil
+	}
+}
+
+// 连接现场数据源并持续读取现场数据，直到断开。name 为回复消息类型的前缀
+func (h *Hub) runSource(ctx context.Context, source connector.Source, name string) {
+	err := source.Connect(ctx)
+	if err != nil {
+		log.WithFields(log.Fields{"source": name, "err": err}).Error("连接现场数据源失败")
+		h.replyError(name+"_error", err)
+		return
+	}
+	defer source.Close()
+	h.reply(name+"_connected", name+"_connected")
+	source.Run(ctx)
+}
+
+// 开始新的 MQTT 发布，已有的发布先停止
+func (h *Hub) startMqtt(cfg model.MqttCfg) context.Context {
+	h.jobMu.Lock()
+	defer h.jobMu.Unlock()
+	if h.publishing != nil {
+		h.publishing()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h.publishing, h.mqtt = cancel, nil
+	return ctx
+}
+
+// 停止 MQTT 发布
+func (h *Hub) stopMqtt() {
+	h.jobMu.Lock()
+	defer h.jobMu.Unlock()
+	if h.publishing != nil {
+		h.publishing()
+		h.publishing, h.mqtt = nil, nil
+	}
+}
+
+// 连接 MQTT 服务器，连接成功后随温度场推送发布关键指标，直到停止
+func (h *Hub) runMqtt(ctx context.Context, cfg model.MqttCfg) {
+	m := publisher.NewMqtt(cfg)
+	if err := m.Connect(); err != nil {
+		log.WithField("err", err).Error("连接 MQTT 服务器失败")
+		h.replyError("mqtt_error", err)
+		return
+	}
+	h.jobMu.Lock()
+	if ctx.Err() == nil {
+		h.mqtt = m
+	}
+	h.jobMu.Unlock()
+	h.reply("mqtt_connected", "mqtt_connected")
+	m.Run(ctx)
+}
+
+// 运行离线优化任务，推送进度和结果
+func (h *Hub) runOptimize(ctx context.Context, cfg model.OptimizeCfg) {
+	defer func() {
+		h.jobMu.Lock()
+		h.optimizing()
+		h.optimizing = nil
+		h.jobMu.Unlock()
+	}()
+	result, err := h.c.Optimize(ctx, cfg, func(p calculator.OptimizeProgress) {
+		data, err := json.Marshal(p)
+		if err != nil {
+			log.WithField("err", err).Error("优化进度json解析失败")
+			return
+		}
+		h.reply("optimize_progress", string(data))
+	})
+	if err != nil {
+		log.WithField("err", err).Warn("冷却制度优化未完成")
+		h.replyError("optimize_error", err)
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.WithField("err", err).Error("优化结果json解析失败")
+		return
+	}
+	h.reply("optimize_result", string(data))
+}
+
+// 回复消息
+func (h *Hub) reply(typ, content string) {
+	reply := model.Msg{
+		Type:    typ,
+		Content: content,
+	}
+	h.mu.Lock()
+	err := h.conn.WriteJSON(&reply)
+	h.mu.Unlock()
+	if err != nil {
+		log.WithField("err", err).Error("回复消息失败")
+	}
+}
+
+// 回复 json 格式的消息，序列化失败时只跳过这一条消息，desc 用于日志
+func (h *Hub) replyJSON(typ string, v interface{}, desc string) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.WithField("err", err).Error(desc + "json解析失败")
+		return
+	}
+	h.reply(typ, string(data))
+}
+
+// 回复错误信息，内容为校验错误列表
+func (h *Hub) replyError(typ string, err error) {
+	data, e := json.Marshal(validation.From(err))
+	if e != nil {
+		log.WithField("err", e).Error("错误信息json解析失败")
+		return
+	}
+	h.reply(typ, string(data))
+}
+
 // 周期性的推送温度场云图数据
-func (h *Hub) pushData() {
+func (h *Hub) pushData(rec *recorder) {
+	defer h.pushing.Done()
 	reply := model.Msg{
 		Type: "data_push",
 	}
@@ -394,6 +1373,7 @@ LOOP:
 		case <-h.c.GetCalcHub().Stop:
 			break LOOP
 		case <-h.c.GetCalcHub().PeriodCalcResult:
+			start := time.Now()
 			temperatureData := h.c.BuildData()
 			data, err := json.Marshal(temperatureData)
 			if err != nil {
@@ -407,6 +1387,29 @@ LOOP:
 			if err != nil {
 				log.WithField("err", err).Error("发送温度场推送消息失败")
 			}
+			observePush("websocket", start)
+			// 发布关键指标和报警、记录历史数据，不受后面附加推送的影响
+			h.jobMu.Lock()
+			m := h.mqtt
+			h.jobMu.Unlock()
+			if m != nil {
+				m.Publish(h.c.GenerateIndicators())
+			}
+			rec.record(h.c)
+			// 随温度场一起更新动态轻压下方案
+			h.replyJSON("soft_reduction_plan", h.c.GenerateSoftReductionPlan(), "动态轻压下方案")
+			// 有液面测量值时推送弯月面稳定性
+			if stability := h.c.GetCastingMachine().GetMeniscusStability(); stability.Samples > 0 {
+				h.replyJSON("meniscus_stability", stability, "弯月面稳定性")
+			}
+			// 配置了测温仪时推送测量值与计算值的比较
+			if report := h.c.GetCastingMachine().GetPyrometerReport(); len(report.Points) > 0 {
+				h.replyJSON("pyrometer_comparison", report, "测温仪比较结果")
+			}
+			// 推送动态二冷控制动作
+			if dynamicCooling := h.c.GenerateDynamicCoolingData(); dynamicCooling.Enabled {
+				h.replyJSON("dynamic_cooling_actions", dynamicCooling, "动态二冷控制动作")
+			}
 		}
 	}
 }
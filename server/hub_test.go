@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"lz/caster"
+	"lz/conf"
+	"lz/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 发送一条 websocket 消息
+func send(t *testing.T, conn *websocket.Conn, typ string, content interface{}) {
+	msg := model.Msg{Type: typ}
+	if s, ok := content.(string); ok {
+		msg.Content = s
+	} else {
+		data, _ := json.Marshal(content)
+		msg.Content = string(data)
+	}
+	if err := conn.WriteJSON(&msg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 读取下一条不是温度场推送的回复
+func receive(t *testing.T, conn *websocket.Conn) model.Msg {
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		var msg model.Msg
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Type != "data_push" {
+			return msg
+		}
+	}
+}
+
+func TestHubEnvNotSet(t *testing.T) {
+	s := &Server{
+		casters: caster.NewRepository(conf.AppConfig.CasterHomePath),
+		hubs:    make(map[*Hub]bool),
+	}
+	ts := httptest.NewServer(http.HandlerFunc(s.serveWs))
+	defer ts.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	md := testEnv(t).Md
+	send(t, conn, "change_md", md)
+	if msg := receive(t, conn); msg.Type != "env_not_set" {
+		t.Fatal("未设置计算环境时应回复 env_not_set:", msg)
+	}
+	send(t, conn, "env", testEnv(t))
+	if msg := receive(t, conn); msg.Type != "env_set" {
+		t.Fatal("设置计算环境失败:", msg)
+	}
+	send(t, conn, "change_md", md)
+	if msg := receive(t, conn); msg.Type != "md_set" {
+		t.Fatal("设置计算环境后应能修改结晶器参数:", msg)
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// 温度场推送耗时，从计算器发出推送信号到数据写入连接
+var pushDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "lz",
+	Name:      "push_duration_seconds",
+	Help:      "温度场推送耗时，包括生成、序列化和发送数据",
+	Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+}, []string{"transport"})
+
+func observePush(transport string, start time.Time) {
+	pushDuration.WithLabelValues(transport).Observe(time.Since(start).Seconds())
+}
+
+var (
+	sessionsDesc = prometheus.NewDesc("lz_sessions", "当前的会话数，websocket 为连接数，simulation 为 HTTP 和 gRPC 共用的仿真数", []string{"type"}, nil)
+	queueDesc    = prometheus.NewDesc("lz_hub_queue_depth", "所有 websocket 连接中各个通道等待处理的消息数之和", []string{"channel"}, nil)
+)
+
+// 输出默认注册表中的指标和服务自身的指标
+func (s *Server) metricsHandler() http.Handler {
+	return promhttp.HandlerFor(prometheus.Gatherers{prometheus.DefaultGatherer, s.registry}, promhttp.HandlerOpts{})
+}
+
+// 采集时读取服务的会话数和通道积压情况
+type serverCollector struct {
+	s *Server
+}
+
+func (sc serverCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sessionsDesc
+	ch <- queueDesc
+}
+
+func (sc serverCollector) Collect(ch chan<- prometheus.Metric) {
+	sc.s.hubMu.Lock()
+	hubs := len(sc.s.hubs)
+	depths := make(map[string]int)
+	for hub := range sc.s.hubs {
+		hub.queueDepths(depths)
+	}
+	sc.s.hubMu.Unlock()
+	sc.s.simMu.Lock()
+	simulations := len(sc.s.simulations)
+	sc.s.simMu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(hubs), "websocket")
+	ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(simulations), "simulation")
+	for name, depth := range depths {
+		ch <- prometheus.MustNewConstMetric(queueDesc, prometheus.GaugeValue, float64(depth), name)
+	}
+}
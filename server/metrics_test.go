@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"lz/conf"
+	"lz/model"
+)
+
+func TestServerCollector(t *testing.T) {
+	s := &Server{
+		hubs:        make(map[*Hub]bool),
+		simulations: map[string]*simulation{"1": {}},
+	}
+	for i := 0; i < 2; i++ {
+		hub := NewHub()
+		hub.msg <- model.Msg{}
+		hub.changeV <- 1.2
+		s.hubs[hub] = true
+	}
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(serverCollector{s})
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			got[family.GetName()+"/"+m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+	expected := map[string]float64{
+		"lz_sessions/websocket":           2,
+		"lz_sessions/simulation":          1,
+		"lz_hub_queue_depth/msg":          2,
+		"lz_hub_queue_depth/changeV":      2,
+		"lz_hub_queue_depth/changeMd":     0,
+		"lz_hub_queue_depth/moldLevel":    0,
+		"lz_hub_queue_depth/connectOpcUa": 0,
+	}
+	for name, value := range expected {
+		if v, ok := got[name]; !ok || v != value {
+			t.Fatalf("%s 应为 %v: %v %v", name, value, v, ok)
+		}
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	// 不打开历史数据库
+	path := conf.AppConfig.HistoryPath
+	conf.AppConfig.HistoryPath = ""
+	defer func() { conf.AppConfig.HistoryPath = path }()
+	// 同一进程中可以创建多个服务，各自的指标互不影响
+	s := NewServer(":0", websocket.Upgrader{})
+	other := NewServer(":0", websocket.Upgrader{})
+	other.simulations["1"] = &simulation{}
+
+	rec := httptest.NewRecorder()
+	s.metricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), `lz_sessions{type="simulation"} 0`) {
+		t.Fatal("应输出服务自身的会话数:", rec.Body.String())
+	}
+}
@@ -1,28 +1,62 @@
 package server
 
 import (
+	"encoding/json"
 	"flag"
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
 	"log"
+	"lz/caster"
+	"lz/conf"
+	"lz/historian"
 	"lz/model"
 	"net/http"
+	"sync"
+	"time"
 )
 
 type Server struct {
 	addr     string
 	upgrader websocket.Upgrader
+	casters  *caster.Repository   // 所有连接共享的铸机库
+	history  *historian.Historian // 所有连接共享的历史数据库
+	registry *prometheus.Registry // 服务自身的指标，与默认注册表中的计算指标一起在 /metrics 输出
+
+	hubMu sync.Mutex
+	hubs  map[*Hub]bool // 当前的 websocket 连接
+
+	simMu          sync.Mutex
+	simulations    map[string]*simulation // HTTP 接口中的仿真
+	nextSimulation int
 }
 
 func NewServer(addr string, upgrader websocket.Upgrader) *Server {
-	return &Server{
+	s := &Server{
 		addr:     addr,
 		upgrader: upgrader,
+		casters:  caster.NewRepository(conf.AppConfig.CasterHomePath),
+
+		hubs:        make(map[*Hub]bool),
+		simulations: make(map[string]*simulation),
 	}
+	if conf.AppConfig.HistoryPath != "" {
+		history, err := historian.NewHistorian(conf.AppConfig.HistoryPath, time.Duration(conf.AppConfig.HistoryRetention)*time.Hour)
+		if err != nil {
+			log.Println("打开历史数据库失败，不记录历史数据: ", err)
+		} else {
+			s.history = history
+		}
+	}
+	s.registry = prometheus.NewRegistry()
+	s.registry.MustRegister(serverCollector{s})
+	return s
 }
 
 // serveWs handles websocket requests from the peer.
 func (s *Server) serveWs(w http.ResponseWriter, r *http.Request) {
 	hub := NewHub()
+	hub.casters = s.casters
+	hub.history = s.history
 	conn, err := s.upgrader.Upgrade(w, r, nil)
 	hub.conn = conn
 	if err != nil {
@@ -30,12 +64,26 @@ func (s *Server) serveWs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	s.hubMu.Lock()
+	s.hubs[hub] = true
+	s.hubMu.Unlock()
+	defer func() {
+		s.hubMu.Lock()
+		delete(s.hubs, hub)
+		s.hubMu.Unlock()
+		hub.disconnected <- struct{}{}
+	}()
 	var msg model.Msg
 	go hub.handleRequest()
 	go hub.handleResponse()
 	for {
-		err = conn.ReadJSON(&msg)
+		// 连接断开后读取会一直失败，此时结束会话
+		_, data, err := conn.ReadMessage()
 		if err != nil {
+			log.Println("err: ", err)
+			return
+		}
+		if err = json.Unmarshal(data, &msg); err != nil {
 			log.Println("err: ", err)
 			continue
 		}
@@ -48,6 +96,11 @@ func (s *Server) Serve() {
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
 		s.serveWs(w, r)
 	})
+	http.Handle("/api/", s.apiHandler())
+	http.Handle("/metrics", s.metricsHandler())
+	if conf.AppConfig.GrpcPort != "" {
+		go s.serveGrpc(conf.AppConfig.GrpcPort)
+	}
 	err := http.ListenAndServe(s.addr, nil)
 	if err != nil {
 		log.Fatal("ListenAndServe: ", err)
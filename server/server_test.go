@@ -0,0 +1,58 @@
+package server
+
+import (
+	"lz/caster"
+	"lz/conf"
+	"lz/model"
+	"os"
+	"testing"
+)
+
+// 配置文件中的路径相对项目目录
+func TestMain(m *testing.M) {
+	if err := os.Chdir(".."); err != nil {
+		panic(err)
+	}
+	conf.Init()
+	os.Exit(m.Run())
+}
+
+// 按配置目录中的铸机生成合法的计算环境
+func testEnv(t *testing.T) model.Env {
+	c, err := caster.NewRepository(conf.AppConfig.CasterHomePath).Get("caster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := model.Env{
+		Caster:           c.Name,
+		LevelHeight:      c.Coordinate.LevelHeight,
+		SteelValue:       3,
+		StartTemperature: 1530,
+		DragSpeed:        1.5,
+		Coordinate:       c.Coordinate,
+		Md: model.Md{
+			NarrowSurfaceIn:     30,
+			NarrowSurfaceOut:    38,
+			NarrowSurfaceVolume: 300,
+			WideSurfaceIn:       30,
+			WideSurfaceOut:      38,
+			WideSurfaceVolume:   3000,
+		},
+	}
+	for _, zone := range c.CoolingZone {
+		env.CoolingZoneCfg = append(env.CoolingZoneCfg, model.CoolingZone{
+			ZoneName: zone.ZoneName,
+			Start:    zone.Start,
+			End:      zone.End,
+			Medium:   zone.Medium,
+		})
+		env.SecondaryCoolingWaterCfg = append(env.SecondaryCoolingWaterCfg, model.SecondaryCoolingWaterSection{
+			SprayWaterTemperature: zone.SprayWaterTemperature,
+			InnerArcWaterVolume:   zone.InnerArcVolume,
+			NarrowSideWaterVolume: zone.NarrowSideVolume,
+			Fuqie1Volume:          zone.Fuqie1Volume,
+			Fuqie2Volume:          zone.Fuqie2Volume,
+		})
+	}
+	return env
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"lz/model"
+)
+
+// 校验铸机定义
+func Caster(caster *model.Caster) Errors {
+	var errs Errors
+	errs = append(errs, Coordinate("coordinate", caster.Coordinate)...)
+	rollers := len(caster.SecondaryCoolingZone)
+	pre := 0
+	for i, zone := range caster.CoolingZone {
+		field := index("cooling_zone", i)
+		if zone.Start != pre+1 || zone.End < zone.Start {
+			errs.add(field, "冷却区 %s 辊子范围 [%d, %d] 不连续", zone.ZoneName, zone.Start, zone.End)
+		}
+		if rollers > 0 && zone.End > rollers {
+			errs.add(field+".end", "辊子下标越界: %d > %d", zone.End, rollers)
+		}
+		errs = append(errs, SecondaryCoolingWater(field, model.SecondaryCoolingWaterSection{
+			SprayWaterTemperature: zone.SprayWaterTemperature,
+			InnerArcWaterVolume:   zone.InnerArcVolume,
+			NarrowSideWaterVolume: zone.NarrowSideVolume,
+			Fuqie1Volume:          zone.Fuqie1Volume,
+			Fuqie2Volume:          zone.Fuqie2Volume,
+		})...)
+		pre = zone.End
+	}
+	for i := 1; i < rollers; i++ {
+		if caster.SecondaryCoolingZone[i].Distance <= caster.SecondaryCoolingZone[i-1].Distance {
+			errs.add(index("secondary_cooling_zone", i)+".distance", "辊子 %d 的距离不大于前一个辊子", caster.SecondaryCoolingZone[i].RollerNum)
+		}
+	}
+	pre = 0
+	for i, seg := range caster.Segments {
+		field := index("segments", i)
+		if seg.Start != pre+1 || seg.End < seg.Start || seg.End > rollers {
+			errs.add(field, "扇形段 %s 辊子范围 [%d, %d] 不连续或越界", seg.Name, seg.Start, seg.End)
+		}
+		if seg.EntryThickness < 0 || seg.ExitThickness < 0 {
+			errs.add(field, "扇形段 %s 辊缝不能为负数", seg.Name)
+		}
+		if seg.ExitThickness > seg.EntryThickness {
+			errs.add(field+".exit_thickness", "扇形段 %s 出口辊缝 %.1f 大于入口辊缝 %.1f", seg.Name, seg.ExitThickness, seg.EntryThickness)
+		}
+		if float32(caster.Coordinate.Width) < seg.EntryThickness {
+			errs.add(field+".entry_thickness", "扇形段 %s 辊缝 %.1f 大于铸坯厚度 %d", seg.Name, seg.EntryThickness, caster.Coordinate.Width)
+		}
+		pre = seg.End
+	}
+	for i, ems := range caster.Ems {
+		if ems.EndDistance <= ems.StartDistance || ems.Factor <= 0 {
+			errs.add(index("ems", i), "电磁搅拌 %s 作用范围或影响因子错误", ems.Name)
+		}
+	}
+	if caster.NozzleCfg != nil {
+		errs = append(errs, Nozzle("nozzle_cfg", *caster.NozzleCfg, len(caster.CoolingZone))...)
+	}
+	return errs
+}
+
+// 校验铸机的冷却区、辊子与喷嘴布置是否一致
+func CasterNozzle(caster *model.Caster, nozzleCfg model.NozzleCfg) Errors {
+	var errs Errors
+	rollers := caster.SecondaryCoolingZone
+	if len(rollers) > 0 && len(nozzleCfg.WideItems) != len(rollers) {
+		errs.add("nozzle_cfg.wide_items", "喷嘴布置辊子数 %d 与铸机辊子数 %d 不一致", len(nozzleCfg.WideItems), len(rollers))
+	}
+	for i, item := range nozzleCfg.WideItems {
+		if i < len(rollers) && abs(item.Distance-rollers[i].Distance) > MaxRollerDistanceDeviation {
+			errs.add(index("nozzle_cfg.wide_items", i)+".distance", "辊子 %d 距离 %.1f 与铸机定义 %.1f 不一致", item.RollerNum, item.Distance, rollers[i].Distance)
+		}
+	}
+	zones := make([]model.CoolingZone, len(caster.CoolingZone))
+	for i, zone := range caster.CoolingZone {
+		zones[i] = model.CoolingZone{ZoneName: zone.ZoneName, Start: zone.Start, End: zone.End}
+	}
+	errs = append(errs, zoneMismatch("nozzle_cfg.wide_items", zones, nozzleCfg)...)
+	return errs
+}
+
+// 喷嘴所在辊子的冷却区与冷却区辊子范围不一致
+func zoneMismatch(field string, zones []model.CoolingZone, nozzleCfg model.NozzleCfg) Errors {
+	var errs Errors
+	for i, item := range nozzleCfg.WideItems {
+		for j, zone := range zones {
+			if item.RollerNum >= zone.Start && item.RollerNum <= zone.End && item.CoolingZone != j+1 {
+				errs.add(index(field, i)+".cooling_zone", "辊子 %d 属于冷却区 %s，喷嘴布置中为冷却区 %d", item.RollerNum, zone.ZoneName, item.CoolingZone)
+			}
+		}
+	}
+	return errs
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,620 @@
+package validation
+
+import (
+	"fmt"
+	"lz/model"
+	"net"
+	"strings"
+	"time"
+)
+
+// 校验计算环境，nozzleCfg 为该铸机的喷嘴布置
+func Env(env model.Env, nozzleCfg model.NozzleCfg) Errors {
+	var errs Errors
+	errs = append(errs, Coordinate("coordinate", env.Coordinate)...)
+	if env.LevelHeight < 0 || int(env.LevelHeight) >= env.Coordinate.MdLength {
+		errs.add("level_height", "液面高度 %.1f 必须在 [0, %d) 之间", env.LevelHeight, env.Coordinate.MdLength)
+	}
+	errs = append(errs, castingTemperature("start_temperature", env.StartTemperature)...)
+	if env.DragSpeed <= 0 || env.DragSpeed > MaxDragSpeed {
+		errs.add("drag_speed", "拉速 %.2f 必须在 (0, %.0f] 之间", env.DragSpeed, MaxDragSpeed)
+	}
+	errs = append(errs, Md("md", env.Md)...)
+	if len(env.SecondaryCoolingWaterCfg) != len(env.CoolingZoneCfg) {
+		errs.add("secondary_cooling_water_cfg", "二冷水量配置数 %d 与冷却区数 %d 不一致", len(env.SecondaryCoolingWaterCfg), len(env.CoolingZoneCfg))
+	}
+	for i, section := range env.SecondaryCoolingWaterCfg {
+		errs = append(errs, SecondaryCoolingWater(index("secondary_cooling_water_cfg", i), section)...)
+	}
+	errs = append(errs, CoolingZones("cooling_zone_cfg", env.CoolingZoneCfg, nozzleCfg)...)
+	errs = append(errs, Nozzle("nozzle_cfg", nozzleCfg, len(env.CoolingZoneCfg))...)
+	return errs
+}
+
+// 校验铸机尺寸
+func Coordinate(field string, coordinate model.Coordinate) Errors {
+	var errs Errors
+	if coordinate.Width <= 0 || coordinate.Width/2 > model.Width {
+		errs.add(field+".width", "窄面长度 %d 必须在 (0, %d] 之间", coordinate.Width, model.Width*2)
+	}
+	if coordinate.Length <= 0 || coordinate.Length/2 > model.Length {
+		errs.add(field+".length", "宽面长度 %d 必须在 (0, %d] 之间", coordinate.Length, model.Length*2)
+	}
+	if coordinate.MdLength <= 0 {
+		errs.add(field+".md_length", "结晶器长度 %d 必须为正数", coordinate.MdLength)
+	}
+	if coordinate.ZLength <= coordinate.MdLength {
+		errs.add(field+".z_length", "铸机长度 %d 必须大于结晶器长度 %d", coordinate.ZLength, coordinate.MdLength)
+	}
+	if coordinate.R < 0 {
+		errs.add(field+".r", "弧形半径 %.1f 不能为负数", coordinate.R)
+	}
+	if coordinate.CenterStartDistance > coordinate.CenterEndDistance || coordinate.CenterEndDistance > float32(coordinate.ZLength) {
+		errs.add(field+".center_end_distance", "弧形段 [%.1f, %.1f] 超出铸机范围", coordinate.CenterStartDistance, coordinate.CenterEndDistance)
+	}
+	if coordinate.ArcStartDistance > coordinate.ArcEndDistance {
+		errs.add(field+".arc_end_distance", "弧形段外弧 [%.1f, %.1f] 起止位置错误", coordinate.ArcStartDistance, coordinate.ArcEndDistance)
+	}
+	var preDistance float32
+	for i, zone := range coordinate.BendingZones {
+		if zone.StartDistance < preDistance || zone.EndDistance <= zone.StartDistance || zone.EndDistance > float32(coordinate.ZLength) {
+			errs.add(index(field+".bending_zones", i), "弯曲区 [%.1f, %.1f] 与前一区重叠或超出铸机范围", zone.StartDistance, zone.EndDistance)
+		}
+		if zone.StartRadius < 0 || zone.EndRadius < 0 {
+			errs.add(index(field+".bending_zones", i), "弯曲半径 [%.1f, %.1f] 不能为负数", zone.StartRadius, zone.EndRadius)
+		}
+		preDistance = zone.EndDistance
+	}
+	return errs
+}
+
+// 校验结晶器冷却参数
+func Md(field string, md model.Md) Errors {
+	var errs Errors
+	errs = append(errs, waterTemperature(field+".narrow_surface_in", md.NarrowSurfaceIn)...)
+	errs = append(errs, waterTemperature(field+".narrow_surface_out", md.NarrowSurfaceOut)...)
+	errs = append(errs, waterTemperature(field+".wide_surface_in", md.WideSurfaceIn)...)
+	errs = append(errs, waterTemperature(field+".wide_surface_out", md.WideSurfaceOut)...)
+	if md.NarrowSurfaceOut <= md.NarrowSurfaceIn {
+		errs.add(field+".narrow_surface_out", "窄面出水温度 %.1f 必须高于入水温度 %.1f", md.NarrowSurfaceOut, md.NarrowSurfaceIn)
+	}
+	if md.WideSurfaceOut <= md.WideSurfaceIn {
+		errs.add(field+".wide_surface_out", "宽面出水温度 %.1f 必须高于入水温度 %.1f", md.WideSurfaceOut, md.WideSurfaceIn)
+	}
+	if md.NarrowSurfaceVolume <= 0 {
+		errs.add(field+".narrow_surface_volume", "窄面水量 %.1f 必须为正数", md.NarrowSurfaceVolume)
+	}
+	if md.WideSurfaceVolume <= 0 {
+		errs.add(field+".wide_surface_volume", "宽面水量 %.1f 必须为正数", md.WideSurfaceVolume)
+	}
+	return errs
+}
+
+// 校验结晶器单个面的冷却参数，水量为 0 表示不修改水量
+func MdSurface(field string, in, out, volume float32) Errors {
+	var errs Errors
+	errs = append(errs, waterTemperature(field+".in", in)...)
+	errs = append(errs, waterTemperature(field+".out", out)...)
+	if out <= in {
+		errs.add(field+".out", "出水温度 %.1f 必须高于入水温度 %.1f", out, in)
+	}
+	if volume < 0 {
+		errs.add(field+".volume", "水量 %.1f 不能为负数", volume)
+	}
+	return errs
+}
+
+// 校验单个冷却区的二冷水量，水量为 0 表示该区空冷
+func SecondaryCoolingWater(field string, section model.SecondaryCoolingWaterSection) Errors {
+	var errs Errors
+	errs = append(errs, waterTemperature(field+".spray_water_temperature", section.SprayWaterTemperature)...)
+	volumes := []struct {
+		name   string
+		volume float32
+	}{
+		{"inner_arc_water_volume", section.InnerArcWaterVolume},
+		{"narrow_side_water_volume", section.NarrowSideWaterVolume},
+		{"fuqie_1_volume", section.Fuqie1Volume},
+		{"fuqie_2_volume", section.Fuqie2Volume},
+	}
+	for _, v := range volumes {
+		if v.volume < 0 {
+			errs.add(field+"."+v.name, "水量 %.1f 不能为负数", v.volume)
+		}
+	}
+	return errs
+}
+
+// 校验冷却区的辊子范围，冷却区必须连续且不超过喷嘴布置中的辊子数
+func CoolingZones(field string, zones []model.CoolingZone, nozzleCfg model.NozzleCfg) Errors {
+	var errs Errors
+	rollers := len(nozzleCfg.WideItems)
+	pre := 0
+	for i, zone := range zones {
+		if zone.Start != pre+1 || zone.End < zone.Start {
+			errs.add(index(field, i), "冷却区 %s 辊子范围 [%d, %d] 不连续", zone.ZoneName, zone.Start, zone.End)
+		}
+		if zone.End > rollers {
+			errs.add(index(field, i)+".end", "辊子下标越界: %d > %d", zone.End, rollers)
+		}
+		pre = zone.End
+	}
+	errs = append(errs, zoneMismatch("nozzle_cfg.wide_items", zones, nozzleCfg)...)
+	return errs
+}
+
+// 校验喷嘴布置，zones 为冷却区数量
+func Nozzle(field string, nozzleCfg model.NozzleCfg, zones int) Errors {
+	var errs Errors
+	if len(nozzleCfg.WideItems) == 0 {
+		errs.add(field+".wide_items", "喷嘴布置为空")
+	}
+	var preDistance float32
+	for i, item := range nozzleCfg.WideItems {
+		if item.CoolingZone < 1 || item.CoolingZone > zones {
+			errs.add(index(field+".wide_items", i)+".cooling_zone", "冷却区 %d 不存在", item.CoolingZone)
+		}
+		if item.Distance <= preDistance {
+			errs.add(index(field+".wide_items", i)+".distance", "辊子距离 %.1f 不大于前一个辊子 %.1f", item.Distance, preDistance)
+		}
+		if item.RollerDistance <= 0 {
+			errs.add(index(field+".wide_items", i)+".roller_distance", "辊间距 %.1f 必须为正数", item.RollerDistance)
+		}
+		preDistance = item.Distance
+	}
+	for i, item := range nozzleCfg.NarrowItems {
+		if item.CoolingZone < 1 || item.CoolingZone > zones {
+			errs.add(index(field+".narrow_items", i)+".cooling_zone", "冷却区 %d 不存在", item.CoolingZone)
+		}
+		if item.RollerDistance <= 0 {
+			errs.add(index(field+".narrow_items", i)+".roller_distance", "辊间距 %.1f 必须为正数", item.RollerDistance)
+		}
+	}
+	return errs
+}
+
+func waterTemperature(field string, temp float32) Errors {
+	var errs Errors
+	if temp < 0 || temp >= MaxWaterTemperature {
+		errs.add(field, "水温 %.1f 必须在 [0, %.0f) 之间", temp, MaxWaterTemperature)
+	}
+	return errs
+}
+
+// 校验动态轻压下配置
+func SoftReduction(field string, cfg model.SoftReductionCfg) Errors {
+	var errs Errors
+	if cfg.StartSolidFraction < 0 || cfg.EndSolidFraction > 1 || cfg.StartSolidFraction >= cfg.EndSolidFraction {
+		errs.add(field+".end_solid_fraction", "固相率区间 [%.2f, %.2f] 必须在 [0, 1] 之间且起点小于终点", cfg.StartSolidFraction, cfg.EndSolidFraction)
+	}
+	if cfg.ReductionRate < 0 {
+		errs.add(field+".reduction_rate", "压下率 %.2f 不能为负数", cfg.ReductionRate)
+	}
+	if cfg.MaxSegmentReduction < 0 {
+		errs.add(field+".max_segment_reduction", "单段最大压下量 %.2f 不能为负数", cfg.MaxSegmentReduction)
+	}
+	return errs
+}
+
+// 校验在线调宽参数，maxWidth 为允许的最大宽度
+func WidthChange(field string, widthChange model.WidthChange, maxWidth int) Errors {
+	var errs Errors
+	if widthChange.Width <= 0 || widthChange.Width > maxWidth {
+		errs.add(field+".width", "目标宽度 %d 必须在 (0, %d] 之间", widthChange.Width, maxWidth)
+	}
+	if widthChange.Duration < 0 {
+		errs.add(field+".duration", "调宽时间 %.1f 不能为负数", widthChange.Duration)
+	}
+	return errs
+}
+
+// 校验运行时设置的喷嘴状态
+func NozzleStates(field string, states []model.NozzleState) Errors {
+	var errs Errors
+	if len(states) == 0 {
+		errs.add(field, "喷嘴状态为空")
+	}
+	for i, state := range states {
+		if state.Side != "wide" && state.Side != "narrow" {
+			errs.add(index(field, i)+".side", "喷嘴位置 %s 必须为 wide 或 narrow", state.Side)
+		}
+		if e := state.Efficiency; e != nil && (*e < 0 || *e > 1) {
+			errs.add(index(field, i)+".efficiency", "喷嘴效率 %.2f 必须在 [0, 1] 之间", *e)
+		}
+	}
+	return errs
+}
+
+// 校验动态二冷控制配置
+func DynamicCooling(field string, cfg model.DynamicCoolingCfg) Errors {
+	var errs Errors
+	if cfg.Kp < 0 || cfg.Ki < 0 || cfg.Kd < 0 {
+		errs.add(field+".kp", "PID 参数 [%.3f, %.3f, %.3f] 不能为负数", cfg.Kp, cfg.Ki, cfg.Kd)
+	}
+	if cfg.MinVolume < 0 || cfg.MaxVolume <= cfg.MinVolume {
+		errs.add(field+".max_volume", "水量限制 [%.1f, %.1f] 错误", cfg.MinVolume, cfg.MaxVolume)
+	}
+	if cfg.MaxStep < 0 {
+		errs.add(field+".max_step", "单次调整量 %.1f 不能为负数", cfg.MaxStep)
+	}
+	if cfg.Period <= 0 {
+		errs.add(field+".period", "控制周期 %.1f 必须为正数", cfg.Period)
+	}
+	for steel, targets := range cfg.Targets {
+		for zone, temp := range targets {
+			if temp <= 0 || temp >= MinCastingTemperature {
+				errs.add(fmt.Sprintf("%s.targets.%d.%s", field, steel, zone), "目标温度 %.1f 必须在 (0, %.0f) 之间", temp, MinCastingTemperature)
+			}
+		}
+	}
+	return errs
+}
+
+// 校验水表
+func WaterTables(field string, tables []model.WaterTable) Errors {
+	var errs Errors
+	for i, table := range tables {
+		f := index(field, i)
+		if table.ZoneName == "" {
+			errs.add(f+".zone_name", "冷却区名称不能为空")
+		}
+		if table.Speed.Bottom < 0 || table.Speed.Top > MaxDragSpeed || table.Speed.Top < table.Speed.Bottom {
+			errs.add(f+".speed", "拉速范围 [%.2f, %.2f] 必须在 [0, %.0f] 之间", table.Speed.Bottom, table.Speed.Top, MaxDragSpeed)
+		}
+		if table.Speed.Step < 0 {
+			errs.add(f+".speed.step", "拉速步长 %.2f 不能为负数", table.Speed.Step)
+		}
+		for j, point := range table.Points {
+			if j > 0 && point.Speed <= table.Points[j-1].Speed {
+				errs.add(index(f+".points", j)+".speed", "拉速 %.2f 必须大于前一个点", point.Speed)
+			}
+			if point.Volume < 0 {
+				errs.add(index(f+".points", j)+".volume", "水量 %.1f 不能为负数", point.Volume)
+			}
+		}
+	}
+	return errs
+}
+
+// 校验离线冷却制度优化请求
+func Optimize(field string, cfg model.OptimizeCfg) Errors {
+	var errs Errors
+	if len(cfg.Speeds) == 0 {
+		errs.add(field+".speeds", "拉速不能为空")
+	}
+	seen := make(map[float32]bool, len(cfg.Speeds))
+	for i, v := range cfg.Speeds {
+		if v <= 0 || v > MaxDragSpeed {
+			errs.add(index(field+".speeds", i), "拉速 %.2f 必须在 (0, %.0f] 之间", v, MaxDragSpeed)
+		}
+		if seen[v] {
+			errs.add(index(field+".speeds", i), "拉速 %.2f 重复", v)
+		}
+		seen[v] = true
+	}
+	for zone, temp := range cfg.TargetTemperature {
+		if temp <= 0 || temp >= MinCastingTemperature {
+			errs.add(field+".target_temperature."+zone, "目标温度 %.1f 必须在 (0, %.0f) 之间", temp, MinCastingTemperature)
+		}
+	}
+	if cfg.MaxReheatingRate < 0 || cfg.MinUnbendingTemperature < 0 || cfg.MaxCraterEnd < 0 {
+		errs.add(field, "约束条件不能为负数")
+	}
+	if cfg.MinVolume < 0 || cfg.MaxVolume <= cfg.MinVolume {
+		errs.add(field+".max_volume", "水量限制 [%.1f, %.1f] 错误", cfg.MinVolume, cfg.MaxVolume)
+	}
+	if cfg.Iterations <= 0 {
+		errs.add(field+".iterations", "评估次数 %d 必须为正数", cfg.Iterations)
+	}
+	return errs
+}
+
+// 校验拉速曲线，时间必须递增
+func SpeedSchedule(field string, points []model.SpeedPoint) Errors {
+	var errs Errors
+	if len(points) == 0 {
+		errs.add(field, "拉速曲线为空")
+	}
+	for i, point := range points {
+		if point.Time < 0 || (i > 0 && point.Time <= points[i-1].Time) {
+			errs.add(index(field, i)+".time", "时间 %.1f 必须为非负数且大于前一个点", point.Time)
+		}
+		if point.Speed <= 0 || point.Speed > MaxDragSpeed {
+			errs.add(index(field, i)+".speed", "拉速 %.2f 必须在 (0, %.0f] 之间", point.Speed, MaxDragSpeed)
+		}
+	}
+	return errs
+}
+
+// 校验运行时修改的二冷水量
+func SecondaryCoolingChanges(field string, changes []model.SecondaryCoolingChange) Errors {
+	var errs Errors
+	if len(changes) == 0 {
+		errs.add(field, "二冷水量为空")
+	}
+	for i, change := range changes {
+		if change.Zone < 1 {
+			errs.add(index(field, i)+".zone", "冷却区 %d 不存在", change.Zone)
+		}
+		errs = append(errs, SecondaryCoolingWater(index(field, i)+".section", change.Section)...)
+	}
+	return errs
+}
+
+// 校验中间包温度模型
+func Tundish(field string, cfg model.TundishCfg) Errors {
+	var errs Errors
+	for i, point := range cfg.Points {
+		if point.Time < 0 || (i > 0 && point.Time <= cfg.Points[i-1].Time) {
+			errs.add(index(field+".points", i)+".time", "时间 %.1f 必须为非负数且大于前一个点", point.Time)
+		}
+		errs = append(errs, castingTemperature(index(field+".points", i)+".temperature", point.Temperature)...)
+	}
+	if cfg.CoolingRate < 0 {
+		errs.add(field+".cooling_rate", "降温速率 %.2f 不能为负数", cfg.CoolingRate)
+	}
+	return errs
+}
+
+// 校验换包事件
+func LadleChange(field string, ladle model.LadleChange) Errors {
+	return castingTemperature(field+".temperature", ladle.Temperature)
+}
+
+func castingTemperature(field string, temperature float32) Errors {
+	var errs Errors
+	if temperature < MinCastingTemperature || temperature > MaxCastingTemperature {
+		errs.add(field, "浇铸温度 %.1f 必须在 [%.0f, %.0f] 之间", temperature, MinCastingTemperature, MaxCastingTemperature)
+	}
+	return errs
+}
+
+// 校验炉次事件
+func HeatEvent(field string, event model.HeatEvent) Errors {
+	var errs Errors
+	switch event.Type {
+	case model.HeatOpen, model.HeatLadleChange:
+		if event.HeatID == "" {
+			errs.add(field+".heat_id", "炉次号不能为空")
+		}
+	case model.HeatClose:
+	default:
+		errs.add(field+".type", "炉次事件类型 %s 不存在", event.Type)
+	}
+	if event.Temperature != 0 {
+		errs = append(errs, castingTemperature(field+".temperature", event.Temperature)...)
+	}
+	return errs
+}
+
+// 校验结晶器液面测量值，液面必须在结晶器内
+func MoldLevel(field string, level float32, mdLength int) Errors {
+	var errs Errors
+	if level < 0 || int(level) >= mdLength {
+		errs.add(field, "液面高度 %.1f 必须在 [0, %d) 之间", level, mdLength)
+	}
+	return errs
+}
+
+// 校验结晶器液面设定
+func MoldLevelCfg(field string, cfg model.MoldLevelCfg, mdLength int) Errors {
+	errs := MoldLevel(field+".setpoint", cfg.Setpoint, mdLength)
+	if cfg.Window < 0 {
+		errs.add(field+".window", "窗口 %d 不能为负数", cfg.Window)
+	}
+	return errs
+}
+
+// 校验测温仪配置，测量点需要在铸机范围内
+func Pyrometers(field string, cfg model.PyrometerCfg, coordinate model.Coordinate) Errors {
+	var errs Errors
+	if len(cfg.Points) == 0 {
+		errs.add(field+".points", "没有配置测温仪")
+	}
+	ids := make(map[string]bool)
+	for i, p := range cfg.Points {
+		f := index(field+".points", i)
+		if p.ID == "" {
+			errs.add(f+".id", "编号不能为空")
+		} else if ids[p.ID] {
+			errs.add(f+".id", "编号 %s 重复", p.ID)
+		}
+		ids[p.ID] = true
+		if p.Distance < 0 || int(p.Distance) >= coordinate.ZLength {
+			errs.add(f+".distance", "位置 %.1f 必须在 [0, %d) 之间", p.Distance, coordinate.ZLength)
+		}
+		var half float32
+		switch p.Face {
+		case model.FaceInner, model.FaceOuter:
+			half = float32(coordinate.Length) / 2
+		case model.FaceNarrow:
+			half = float32(coordinate.Width) / 2
+		default:
+			errs.add(f+".face", "表面 %s 不存在", p.Face)
+			continue
+		}
+		if p.Offset < -half || p.Offset > half {
+			errs.add(f+".offset", "距中心线的距离 %.1f 必须在 [%.1f, %.1f] 之间", p.Offset, -half, half)
+		}
+	}
+	if cfg.Window < 0 {
+		errs.add(field+".window", "窗口 %d 不能为负数", cfg.Window)
+	}
+	return errs
+}
+
+// 校验测温仪测量值
+func PyrometerTemperature(field string, temperature float32) Errors {
+	var errs Errors
+	if temperature <= 0 || temperature > MaxCastingTemperature {
+		errs.add(field, "表面温度 %.1f 必须在 (0, %.0f] 之间", temperature, MaxCastingTemperature)
+	}
+	return errs
+}
+
+// 校验现场信号的数值，mdLength 用于校验结晶器液面
+func Signal(field, name string, value float32, mdLength int) Errors {
+	var errs Errors
+	switch name {
+	case model.SignalSpeed:
+		if value <= 0 || value > MaxDragSpeed {
+			errs.add(field, "拉速 %.2f 必须在 (0, %.0f] 之间", value, MaxDragSpeed)
+		}
+	case model.SignalNarrowSurfaceIn, model.SignalNarrowSurfaceOut, model.SignalWideSurfaceIn, model.SignalWideSurfaceOut:
+		errs = append(errs, waterTemperature(field, value)...)
+	case model.SignalTundishTemperature:
+		errs = append(errs, castingTemperature(field, value)...)
+	case model.SignalMoldLevel:
+		errs = append(errs, MoldLevel(field, value, mdLength)...)
+	default:
+		if _, ok := model.PyrometerSignal(name); ok {
+			errs = append(errs, PyrometerTemperature(field, value)...)
+		} else if _, ok := model.ZoneSignal(name); !ok && !isSignal(name) {
+			errs.add(field, "信号 %s 不存在", name)
+		} else if value < 0 {
+			errs.add(field, "水量 %.1f 不能为负数", value)
+		}
+	}
+	return errs
+}
+
+// 校验 OPC UA 数据源配置
+func OpcUa(field string, cfg model.OpcUaCfg) Errors {
+	var errs Errors
+	if !strings.HasPrefix(cfg.Endpoint, "opc.tcp://") {
+		errs.add(field+".endpoint", "地址 %s 必须以 opc.tcp:// 开头", cfg.Endpoint)
+	}
+	if cfg.Interval <= 0 {
+		errs.add(field+".interval", "采样周期 %.2f 必须大于 0", cfg.Interval)
+	}
+	errs = append(errs, signalNames(field+".nodes", cfg.Nodes)...)
+	return errs
+}
+
+// 校验 Modbus TCP 数据源配置
+func Modbus(field string, cfg model.ModbusCfg) Errors {
+	var errs Errors
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		errs.add(field+".address", "地址 %s 不合法: %v", cfg.Address, err)
+	}
+	if cfg.Interval <= 0 {
+		errs.add(field+".interval", "采样周期 %.2f 必须大于 0", cfg.Interval)
+	}
+	if cfg.StaleTimeout < 0 {
+		errs.add(field+".stale_timeout", "失效时间 %.2f 不能为负数", cfg.StaleTimeout)
+	}
+	if len(cfg.Registers) == 0 {
+		errs.add(field+".registers", "没有配置寄存器")
+	}
+	signals := make(map[string]bool)
+	for i, register := range cfg.Registers {
+		f := index(field+".registers", i)
+		errs = append(errs, signalName(f+".signal", register.Signal)...)
+		if signals[register.Signal] {
+			errs.add(f+".signal", "信号 %s 重复", register.Signal)
+		}
+		signals[register.Signal] = true
+		switch register.Type {
+		case "", model.ModbusHolding, model.ModbusInput:
+		default:
+			errs.add(f+".type", "寄存器类型 %s 不存在", register.Type)
+		}
+		switch register.DataType {
+		case "", model.ModbusUint16, model.ModbusInt16, model.ModbusUint32, model.ModbusInt32, model.ModbusFloat32:
+		default:
+			errs.add(f+".data_type", "数据类型 %s 不存在", register.DataType)
+		}
+	}
+	return errs
+}
+
+// 校验 MQTT 发布配置
+func Mqtt(field string, cfg model.MqttCfg) Errors {
+	var errs Errors
+	if _, _, err := net.SplitHostPort(cfg.Broker); err != nil {
+		errs.add(field+".broker", "地址 %s 不合法: %v", cfg.Broker, err)
+	}
+	if cfg.QoS > 1 {
+		errs.add(field+".qos", "QoS %d 只能为 0 或 1", cfg.QoS)
+	} else if cfg.QoS == 1 && cfg.ClientID == "" {
+		// QoS 1 不清除会话，重连后按客户端标识恢复未确认的消息
+		errs.add(field+".client_id", "QoS 为 1 时需要设置客户端标识")
+	}
+	topics := []struct {
+		name  string
+		topic string
+	}{
+		{"mold_exit_shell", cfg.Topics.MoldExitShell},
+		{"crater_end", cfg.Topics.CraterEnd},
+		{"zone_surface_temperature", cfg.Topics.ZoneSurfaceTemperature},
+		{"alarms", cfg.Topics.Alarms},
+	}
+	configured := false
+	for _, t := range topics {
+		if t.topic == "" {
+			continue
+		}
+		configured = true
+		if strings.ContainsAny(t.topic, "+#") {
+			errs.add(field+".topics."+t.name, "发布主题 %s 不能包含通配符", t.topic)
+		}
+	}
+	if !configured {
+		errs.add(field+".topics", "没有配置发布主题")
+	}
+	return errs
+}
+
+// 校验历史数据查询
+func HistoryQuery(field string, q model.HistoryQuery) Errors {
+	var errs Errors
+	switch q.Kind {
+	case model.HistoryIndicators, model.HistoryField:
+	default:
+		errs.add(field+".kind", "历史数据类型 %s 不存在", q.Kind)
+	}
+	if q.Start.IsZero() || q.End.IsZero() {
+		errs.add(field, "必须指定开始时间和结束时间")
+	} else if !q.End.After(q.Start) {
+		errs.add(field+".end", "结束时间 %s 必须晚于开始时间 %s", q.End.Format(time.RFC3339), q.Start.Format(time.RFC3339))
+	}
+	if q.Skip < 0 {
+		errs.add(field+".skip", "跳过的记录条数 %d 不能为负数", q.Skip)
+	}
+	return errs
+}
+
+// 校验数据点配置中的信号名称
+func signalNames(field string, points map[string]string) Errors {
+	var errs Errors
+	if len(points) == 0 {
+		errs.add(field, "没有配置数据点")
+	}
+	for name, point := range points {
+		errs = append(errs, signalName(field+"."+name, name)...)
+		if point == "" {
+			errs.add(field+"."+name, "数据点不能为空")
+		}
+	}
+	return errs
+}
+
+func signalName(field, name string) Errors {
+	var errs Errors
+	if _, ok := model.PyrometerSignal(name); ok {
+		return errs
+	}
+	if _, ok := model.ZoneSignal(name); !ok && !isSignal(name) {
+		errs.add(field, "信号 %s 不存在", name)
+	}
+	return errs
+}
+
+func isSignal(name string) bool {
+	for _, signal := range model.Signals {
+		if signal == name {
+			return true
+		}
+	}
+	return false
+}
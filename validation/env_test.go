@@ -0,0 +1,225 @@
+package validation
+
+import (
+	"lz/model"
+	"testing"
+	"time"
+)
+
+func newTestNozzleCfg() model.NozzleCfg {
+	return model.NozzleCfg{
+		WideItems: []model.WideItem{
+			{RollerNum: 1, CoolingZone: 1, Distance: 930, RollerDistance: 80},
+			{RollerNum: 2, CoolingZone: 1, Distance: 1083, RollerDistance: 153},
+			{RollerNum: 3, CoolingZone: 2, Distance: 1236, RollerDistance: 153},
+		},
+	}
+}
+
+func newTestEnv() model.Env {
+	return model.Env{
+		LevelHeight:      100,
+		StartTemperature: 1530,
+		DragSpeed:        1.5,
+		Md: model.Md{
+			NarrowSurfaceIn:     30,
+			NarrowSurfaceOut:    38,
+			NarrowSurfaceVolume: 540,
+			WideSurfaceIn:       30,
+			WideSurfaceOut:      38,
+			WideSurfaceVolume:   3000,
+		},
+		Coordinate: model.Coordinate{
+			MdLength: 950,
+			Width:    230,
+			Length:   1260,
+			ZLength:  31860,
+		},
+		SecondaryCoolingWaterCfg: []model.SecondaryCoolingWaterSection{
+			{SprayWaterTemperature: 20, InnerArcWaterVolume: 111.5},
+			{SprayWaterTemperature: 20, InnerArcWaterVolume: 207},
+		},
+		CoolingZoneCfg: []model.CoolingZone{
+			{ZoneName: "1 Subarea", Start: 1, End: 2},
+			{ZoneName: "2 Subarea", Start: 3, End: 3},
+		},
+	}
+}
+
+func TestEnv(t *testing.T) {
+	if errs := Env(newTestEnv(), newTestNozzleCfg()); len(errs) > 0 {
+		t.Fatal("合法的计算环境校验失败:", errs)
+	}
+}
+
+func TestEnvInvalid(t *testing.T) {
+	env := newTestEnv()
+	env.StartTemperature = 1700
+	env.Md.WideSurfaceOut = 20
+	env.SecondaryCoolingWaterCfg[1].InnerArcWaterVolume = -1
+	env.CoolingZoneCfg[1].End = 5
+	errs := Env(env, newTestNozzleCfg())
+	fields := map[string]bool{}
+	for _, err := range errs {
+		fields[err.Field] = true
+	}
+	for _, field := range []string{
+		"start_temperature",
+		"md.wide_surface_out",
+		"secondary_cooling_water_cfg[1].inner_arc_water_volume",
+		"cooling_zone_cfg[1].end",
+	} {
+		if !fields[field] {
+			t.Error("缺少字段的校验错误:", field, errs)
+		}
+	}
+}
+
+func TestErrorsErr(t *testing.T) {
+	var errs Errors
+	if errs.Err() != nil {
+		t.Fatal("没有错误时应该返回 nil")
+	}
+}
+
+func TestMdSurface(t *testing.T) {
+	if errs := MdSurface("narrow_surface", 30, 38, 0); len(errs) > 0 {
+		t.Fatal("水量为 0 表示不修改水量，不应报错:", errs)
+	}
+	errs := MdSurface("narrow_surface", 30, 28, -1)
+	fields := map[string]bool{}
+	for _, err := range errs {
+		fields[err.Field] = true
+	}
+	if !fields["narrow_surface.out"] || !fields["narrow_surface.volume"] {
+		t.Fatal("缺少字段的校验错误:", errs)
+	}
+}
+
+func TestTundish(t *testing.T) {
+	cfg := model.TundishCfg{
+		Points:      []model.TundishPoint{{Time: 0, Temperature: 1560}, {Time: 600, Temperature: 1545}},
+		CoolingRate: 0.5,
+	}
+	if errs := Tundish("tundish", cfg); len(errs) > 0 {
+		t.Fatal("合法的中间包温度模型校验失败:", errs)
+	}
+	cfg.Points[1].Time = 0
+	cfg.Points[1].Temperature = 1700
+	cfg.CoolingRate = -1
+	if errs := Tundish("tundish", cfg); len(errs) != 3 {
+		t.Fatal("应有 3 个校验错误:", errs)
+	}
+}
+
+func TestMoldLevelCfg(t *testing.T) {
+	if errs := MoldLevelCfg("mold_level", model.MoldLevelCfg{Setpoint: 100}, 950); len(errs) > 0 {
+		t.Fatal("合法的液面设定校验失败:", errs)
+	}
+	if errs := MoldLevelCfg("mold_level", model.MoldLevelCfg{Setpoint: 950, Window: -1}, 950); len(errs) != 2 {
+		t.Fatal("应有 2 个校验错误:", errs)
+	}
+}
+
+func TestOpcUa(t *testing.T) {
+	cfg := model.OpcUaCfg{
+		Endpoint: "opc.tcp://localhost:4840",
+		Interval: 1,
+		Nodes:    map[string]string{model.SignalSpeed: "ns=2;s=Speed", "zone_1": "ns=2;s=Zone1"},
+	}
+	if errs := OpcUa("opcua", cfg); len(errs) > 0 {
+		t.Fatal("合法的 OPC UA 配置校验失败:", errs)
+	}
+	cfg.Endpoint = "http://localhost"
+	cfg.Nodes["zone_0"] = "ns=2;s=Zone0"
+	if errs := OpcUa("opcua", cfg); len(errs) != 2 {
+		t.Fatal("应有 2 个校验错误:", errs)
+	}
+}
+
+func TestSignal(t *testing.T) {
+	if errs := Signal("speed", model.SignalSpeed, 0, 950); len(errs) != 1 {
+		t.Fatal("拉速为 0 应该被拒绝:", errs)
+	}
+	if errs := Signal("zone_3", "zone_3", 50, 950); len(errs) > 0 {
+		t.Fatal("合法的冷却区水量校验失败:", errs)
+	}
+	if errs := Signal("pyrometer_p1", "pyrometer_p1", 0, 950); len(errs) != 1 {
+		t.Fatal("表面温度为 0 应该被拒绝:", errs)
+	}
+}
+
+func TestPyrometers(t *testing.T) {
+	coordinate := model.Coordinate{ZLength: 30000, Length: 1260, Width: 230}
+	cfg := model.PyrometerCfg{Points: []model.Pyrometer{
+		{ID: "p1", Distance: 5000, Face: model.FaceInner, Offset: -300},
+		{ID: "p2", Distance: 12000, Face: model.FaceNarrow, Offset: 0},
+	}}
+	if errs := Pyrometers("pyrometers", cfg, coordinate); len(errs) > 0 {
+		t.Fatal("合法的测温仪配置校验失败:", errs)
+	}
+	cfg.Points = append(cfg.Points,
+		model.Pyrometer{ID: "p1", Distance: 30000, Face: model.FaceNarrow, Offset: 200},
+		model.Pyrometer{ID: "p3", Face: "top"},
+	)
+	cfg.Window = -1
+	if errs := Pyrometers("pyrometers", cfg, coordinate); len(errs) != 5 {
+		t.Fatal("应有 5 个校验错误:", errs)
+	}
+}
+
+func TestModbus(t *testing.T) {
+	cfg := model.ModbusCfg{
+		Address:  "localhost:502",
+		Interval: 1,
+		Registers: []model.ModbusRegister{
+			{Signal: model.SignalSpeed, Address: 100, DataType: model.ModbusFloat32},
+			{Signal: "zone_1", Address: 11, Type: model.ModbusInput},
+		},
+	}
+	if errs := Modbus("modbus", cfg); len(errs) > 0 {
+		t.Fatal("合法的 Modbus 配置校验失败:", errs)
+	}
+	cfg.Address = "localhost"
+	cfg.Registers = append(cfg.Registers, model.ModbusRegister{Signal: "zone_1", Type: "coil", DataType: "int8"})
+	if errs := Modbus("modbus", cfg); len(errs) != 4 {
+		t.Fatal("应有 4 个校验错误:", errs)
+	}
+}
+
+func TestMqtt(t *testing.T) {
+	cfg := model.MqttCfg{
+		Broker:   "localhost:1883",
+		ClientID: "caster-1",
+		QoS:      1,
+		Topics:   model.MqttTopics{CraterEnd: "caster/1/crater_end"},
+	}
+	if errs := Mqtt("mqtt", cfg); len(errs) > 0 {
+		t.Fatal("合法的 MQTT 配置校验失败:", errs)
+	}
+	cfg.ClientID = ""
+	if errs := Mqtt("mqtt", cfg); len(errs) != 1 || errs[0].Field != "mqtt.client_id" {
+		t.Fatal("QoS 1 时应要求客户端标识:", errs)
+	}
+	cfg.ClientID = "caster-1"
+	cfg.QoS = 2
+	cfg.Topics.Alarms = "caster/+/alarms"
+	if errs := Mqtt("mqtt", cfg); len(errs) != 2 {
+		t.Fatal("应有 2 个校验错误:", errs)
+	}
+	if errs := Mqtt("mqtt", model.MqttCfg{Broker: "localhost"}); len(errs) != 2 {
+		t.Fatal("应有 2 个校验错误:", errs)
+	}
+}
+
+func TestHistoryQuery(t *testing.T) {
+	start := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	q := model.HistoryQuery{Kind: model.HistoryField, Start: start, End: start.Add(time.Hour)}
+	if errs := HistoryQuery("history", q); len(errs) > 0 {
+		t.Fatal("合法的历史数据查询校验失败:", errs)
+	}
+	q.Kind, q.End, q.Skip = "slice", start, -1
+	if errs := HistoryQuery("history", q); len(errs) != 3 {
+		t.Fatal("应有 3 个校验错误:", errs)
+	}
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 温度、拉速等参数的合理范围
+const (
+	MinCastingTemperature = 1400.0 // 浇铸温度下限
+	MaxCastingTemperature = 1600.0 // 浇铸温度上限，超过物性参数表范围
+	MaxWaterTemperature   = 100.0  // 冷却水温度上限
+	MaxDragSpeed          = 10.0   // 拉速上限 m/min
+
+	MaxRollerDistanceDeviation = 1.0 // 喷嘴布置与铸机定义的辊子距离允许偏差 mm
+)
+
+// 单个字段的校验错误
+type Error struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// 校验错误列表，返回给前端
+type Errors []Error
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		if err.Field == "" {
+			messages = append(messages, err.Message)
+		} else {
+			messages = append(messages, err.Field+": "+err.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// 没有错误时返回 nil，避免 nil 切片转换成非 nil 的 error
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *Errors) add(field string, format string, args ...interface{}) {
+	*e = append(*e, Error{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// 将任意错误转换成错误列表
+func From(err error) Errors {
+	if errs, ok := err.(Errors); ok {
+		return errs
+	}
+	return Errors{{Message: err.Error()}}
+}
+
+func index(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}